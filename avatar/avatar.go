@@ -0,0 +1,129 @@
+// Package avatar, yüklenen profil resimlerini doğrular ve kare küçük resimlere dönüştürür.
+package avatar
+
+import (
+	"bytes"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+
+	"golang.org/x/image/draw"
+	"golang.org/x/image/webp"
+)
+
+type AvatarError string
+
+func (e AvatarError) Error() string {
+	return string(e)
+}
+
+const (
+	ErrEmpty           AvatarError = "profil resmi dosyası boş"
+	ErrTooLarge        AvatarError = "profil resmi izin verilen boyutu aşıyor"
+	ErrUnsupportedType AvatarError = "profil resmi JPEG, PNG, GIF veya WebP olmalıdır"
+	ErrInvalidImage    AvatarError = "profil resmi okunamadı"
+	ErrTooManyPixels   AvatarError = "profil resminin çözünürlüğü çok yüksek"
+)
+
+// MaxDimension, kabul edilen en büyük genişlik/yüksekliktir; sıkıştırılmış küçük bir dosyanın bellekte
+// çok büyük bir resme açılmasını önler.
+const MaxDimension = 6000
+
+// Size, üretilen küçük resimlerden biridir.
+type Size struct {
+	Name   string
+	Pixels int
+}
+
+var (
+	Small = Size{Name: "sm", Pixels: 64}
+	Large = Size{Name: "lg", Pixels: 256}
+	Sizes = []Size{Small, Large}
+)
+
+// SizeByName, bilinmeyen adlar için Small döner.
+func SizeByName(name string) Size {
+	for _, size := range Sizes {
+		if size.Name == name {
+			return size
+		}
+	}
+	return Small
+}
+
+var decoders = map[string]func(io.Reader) (image.Image, error){
+	"image/jpeg": jpeg.Decode,
+	"image/png":  png.Decode,
+	"image/gif":  gif.Decode,
+	"image/webp": webp.Decode,
+}
+
+var configDecoders = map[string]func(io.Reader) (image.Config, error){
+	"image/jpeg": jpeg.DecodeConfig,
+	"image/png":  png.DecodeConfig,
+	"image/gif":  gif.DecodeConfig,
+	"image/webp": webp.DecodeConfig,
+}
+
+// Process, r'den en fazla maxBytes okuyup içeriğin gerçekten desteklenen bir resim olduğunu (dosya adı veya
+// istemcinin bildirdiği tipe değil, baytlara bakarak) doğrular ve her boyut için ortadan kırpılmış kare PNG
+// küçük resimler üretir.
+func Process(r io.Reader, maxBytes int64) (map[Size][]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, maxBytes+1))
+	if err != nil {
+		return nil, ErrInvalidImage
+	}
+	if len(data) == 0 {
+		return nil, ErrEmpty
+	}
+	if int64(len(data)) > maxBytes {
+		return nil, ErrTooLarge
+	}
+
+	contentType := http.DetectContentType(data)
+	decode, ok := decoders[contentType]
+	if !ok {
+		return nil, ErrUnsupportedType
+	}
+	config, err := configDecoders[contentType](bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrInvalidImage
+	}
+	if config.Width <= 0 || config.Height <= 0 {
+		return nil, ErrInvalidImage
+	}
+	if config.Width > MaxDimension || config.Height > MaxDimension {
+		return nil, ErrTooManyPixels
+	}
+	source, err := decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrInvalidImage
+	}
+
+	square := centerSquare(source.Bounds())
+	thumbnails := make(map[Size][]byte, len(Sizes))
+	for _, size := range Sizes {
+		target := image.NewNRGBA(image.Rect(0, 0, size.Pixels, size.Pixels))
+		draw.CatmullRom.Scale(target, target.Bounds(), source, square, draw.Src, nil)
+
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, target); err != nil {
+			return nil, err
+		}
+		thumbnails[size] = buf.Bytes()
+	}
+	return thumbnails, nil
+}
+
+func centerSquare(bounds image.Rectangle) image.Rectangle {
+	side := bounds.Dx()
+	if bounds.Dy() < side {
+		side = bounds.Dy()
+	}
+	x := bounds.Min.X + (bounds.Dx()-side)/2
+	y := bounds.Min.Y + (bounds.Dy()-side)/2
+	return image.Rect(x, y, x+side, y+side)
+}
@@ -0,0 +1,60 @@
+package cache
+
+// Stats, bir önbelleğin oluşturulduğundan beri biriken kullanım istatistikleridir.
+type Stats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+	Entries   int
+}
+
+// HitRatio, isabetlerin toplam okumalara oranını 0-1 aralığında döner.
+func (s Stats) HitRatio() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(total)
+}
+
+// HitPercent, isabet oranını en yakın tam sayıya yuvarlanmış yüzde olarak döner.
+func (s Stats) HitPercent() int {
+	return int(s.HitRatio()*100 + 0.5)
+}
+
+// Cache, anahtar-değer önbelleği arayüzüdür. Uygulama içi MemoryCache varsayılan arka uçtur;
+// birden fazla uygulama örneği arasında paylaşılan bir arka uç aynı arayüzü uygulayarak eklenebilir.
+type Cache[K comparable, V any] interface {
+	Get(key K) (V, bool)
+	Set(key K, value V)
+	Delete(keys ...K)
+	Purge()
+	Stats() Stats
+}
+
+// NoopCache, önbellek kapalıyken kullanılır; hiçbir şey saklamaz ve her okumayı ıska sayar.
+type NoopCache[K comparable, V any] struct {
+	misses counter
+}
+
+func NewNoopCache[K comparable, V any]() *NoopCache[K, V] {
+	return &NoopCache[K, V]{}
+}
+
+func (n *NoopCache[K, V]) Get(key K) (V, bool) {
+	n.misses.inc()
+	var zero V
+	return zero, false
+}
+
+func (n *NoopCache[K, V]) Set(key K, value V) {}
+
+func (n *NoopCache[K, V]) Delete(keys ...K) {}
+
+func (n *NoopCache[K, V]) Purge() {}
+
+func (n *NoopCache[K, V]) Stats() Stats {
+	return Stats{Misses: n.misses.load()}
+}
+
+var _ Cache[string, int] = (*NoopCache[string, int])(nil)
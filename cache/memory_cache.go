@@ -0,0 +1,127 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type counter struct {
+	value atomic.Uint64
+}
+
+func (c *counter) inc()         { c.value.Add(1) }
+func (c *counter) load() uint64 { return c.value.Load() }
+
+type memoryEntry[K comparable, V any] struct {
+	key       K
+	value     V
+	expiresAt time.Time
+}
+
+// MemoryCache, süre sınırlı (TTL) ve boyut sınırlı, uygulama içi bir LRU önbelleğidir.
+// Kapasite dolduğunda en uzun süredir kullanılmayan kayıt atılır.
+type MemoryCache[K comparable, V any] struct {
+	mu         sync.Mutex
+	ttl        time.Duration
+	maxEntries int
+	entries    map[K]*list.Element
+	order      *list.List
+
+	hits      counter
+	misses    counter
+	evictions counter
+}
+
+func NewMemoryCache[K comparable, V any](ttl time.Duration, maxEntries int) *MemoryCache[K, V] {
+	return &MemoryCache[K, V]{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		entries:    make(map[K]*list.Element),
+		order:      list.New(),
+	}
+}
+
+func (m *MemoryCache[K, V]) Get(key K) (V, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var zero V
+	element, ok := m.entries[key]
+	if !ok {
+		m.misses.inc()
+		return zero, false
+	}
+
+	entry := element.Value.(*memoryEntry[K, V])
+	if time.Now().After(entry.expiresAt) {
+		m.removeElement(element)
+		m.misses.inc()
+		return zero, false
+	}
+
+	m.order.MoveToFront(element)
+	m.hits.inc()
+	return entry.value, true
+}
+
+func (m *MemoryCache[K, V]) Set(key K, value V) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	expiresAt := time.Now().Add(m.ttl)
+	if element, ok := m.entries[key]; ok {
+		entry := element.Value.(*memoryEntry[K, V])
+		entry.value = value
+		entry.expiresAt = expiresAt
+		m.order.MoveToFront(element)
+		return
+	}
+
+	m.entries[key] = m.order.PushFront(&memoryEntry[K, V]{key: key, value: value, expiresAt: expiresAt})
+	for m.maxEntries > 0 && m.order.Len() > m.maxEntries {
+		m.removeElement(m.order.Back())
+		m.evictions.inc()
+	}
+}
+
+func (m *MemoryCache[K, V]) Delete(keys ...K) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, key := range keys {
+		if element, ok := m.entries[key]; ok {
+			m.removeElement(element)
+		}
+	}
+}
+
+func (m *MemoryCache[K, V]) Purge() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.entries = make(map[K]*list.Element)
+	m.order.Init()
+}
+
+func (m *MemoryCache[K, V]) Stats() Stats {
+	m.mu.Lock()
+	entries := m.order.Len()
+	m.mu.Unlock()
+
+	return Stats{
+		Hits:      m.hits.load(),
+		Misses:    m.misses.load(),
+		Evictions: m.evictions.load(),
+		Entries:   entries,
+	}
+}
+
+func (m *MemoryCache[K, V]) removeElement(element *list.Element) {
+	entry := element.Value.(*memoryEntry[K, V])
+	delete(m.entries, entry.key)
+	m.order.Remove(element)
+}
+
+var _ Cache[string, int] = (*MemoryCache[string, int])(nil)
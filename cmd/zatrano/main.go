@@ -7,6 +7,7 @@ import (
 
 	"zatrano/configs"
 	"zatrano/routes"
+	"zatrano/services"
 	"zatrano/utils"
 
 	"github.com/gofiber/fiber/v2"
@@ -29,13 +30,20 @@ func main() {
 	defer configs.CloseDB()
 
 	configs.InitSession()
+	defer configs.CloseSession()
+	configs.InitMailer()
+	configs.InitStorage()
+	services.InitPasswordPolicy()
+	stopUserTrashPurger := services.StartUserTrashPurger()
+	defer stopUserTrashPurger()
 
 	engine := html.New("./views", ".html")
 	engine.AddFunc("getFlashMessages", utils.GetFlashMessages)
 	engine.AddFuncMap(utils.TemplateHelpers())
 
 	app := fiber.New(fiber.Config{
-		Views: engine,
+		Views:             engine,
+		PassLocalsToViews: true,
 		ErrorHandler: func(c *fiber.Ctx, err error) error {
 			code := fiber.StatusInternalServerError
 			message := "Internal Server Error"
@@ -0,0 +1,27 @@
+package configs
+
+import (
+	"time"
+
+	"zatrano/utils"
+)
+
+// UserCacheConfig, ID ile yapılan kullanıcı okumalarının önbellek ayarlarıdır.
+// Önbellek her uygulama örneğinde ayrı tutulduğundan TTL, diğer örneklerde yapılan
+// değişikliklerin en geç ne kadar sonra görüleceğini belirler.
+type UserCacheConfig struct {
+	TTL        time.Duration
+	MaxEntries int
+}
+
+func GetUserCacheConfig() UserCacheConfig {
+	return UserCacheConfig{
+		TTL:        time.Duration(utils.GetEnvAsInt("USER_CACHE_TTL_SECONDS", 30)) * time.Second,
+		MaxEntries: utils.GetEnvAsInt("USER_CACHE_MAX_ENTRIES", 1000),
+	}
+}
+
+// Enabled, TTL veya kapasite sıfır ya da negatifse önbelleğin kapalı olduğunu belirtir.
+func (c UserCacheConfig) Enabled() bool {
+	return c.TTL > 0 && c.MaxEntries > 0
+}
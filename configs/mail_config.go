@@ -0,0 +1,44 @@
+package configs
+
+import (
+	"zatrano/mailer"
+	"zatrano/utils"
+)
+
+var Mailer mailer.Mailer
+
+func InitMailer() {
+	from := utils.GetEnvWithDefault("MAIL_FROM", "no-reply@zatrano.local")
+
+	switch utils.GetEnvWithDefault("MAIL_DRIVER", "log") {
+	case "smtp":
+		Mailer = mailer.NewSMTPMailer(
+			utils.GetEnvWithDefault("SMTP_HOST", "localhost"),
+			utils.GetEnvAsInt("SMTP_PORT", 587),
+			utils.GetEnvWithDefault("SMTP_USERNAME", ""),
+			utils.GetEnvWithDefault("SMTP_PASSWORD", ""),
+			from,
+		)
+		utils.SLog.Info("SMTP e-posta gönderici yapılandırıldı.")
+	default:
+		// Log göndericisi e-postaları sunucuda bırakır; sıfırlama ve davet bağlantıları bu sayede ele geçirilebileceği
+		// için yalnızca geliştirme ortamında kullanılabilir.
+		if !utils.IsDevelopment() {
+			utils.SLog.Fatal("MAIL_DRIVER=log yalnızca geliştirme ortamında kullanılabilir; MAIL_DRIVER=smtp ayarlayın.")
+		}
+		Mailer = mailer.NewLogMailer(from, utils.GetEnvWithDefault("MAIL_LOG_DIR", "./storage/mail"))
+		utils.SLog.Info("Log tabanlı e-posta gönderici yapılandırıldı.")
+	}
+}
+
+func GetMailer() mailer.Mailer {
+	if Mailer == nil {
+		utils.SLog.Warn("Mailer isteniyor ancak henüz başlatılmamış, şimdi başlatılıyor.")
+		InitMailer()
+	}
+	return Mailer
+}
+
+func GetAppURL() string {
+	return utils.GetEnvWithDefault("APP_URL", "http://localhost:3000")
+}
@@ -0,0 +1,52 @@
+package configs
+
+import (
+	"time"
+
+	"zatrano/utils"
+)
+
+type LoginLockoutConfig struct {
+	MaxAttempts      int
+	IPMaxAttempts    int
+	AttemptWindow    time.Duration
+	BaseLockDuration time.Duration
+	MaxLockDuration  time.Duration
+}
+
+func GetLoginLockoutConfig() LoginLockoutConfig {
+	return LoginLockoutConfig{
+		MaxAttempts:      utils.GetEnvAsInt("LOGIN_MAX_ATTEMPTS", 5),
+		IPMaxAttempts:    utils.GetEnvAsInt("LOGIN_IP_MAX_ATTEMPTS", 20),
+		AttemptWindow:    time.Duration(utils.GetEnvAsInt("LOGIN_ATTEMPT_WINDOW_MINUTES", 60)) * time.Minute,
+		BaseLockDuration: time.Duration(utils.GetEnvAsInt("LOGIN_LOCK_BASE_MINUTES", 1)) * time.Minute,
+		MaxLockDuration:  time.Duration(utils.GetEnvAsInt("LOGIN_LOCK_MAX_MINUTES", 30)) * time.Minute,
+	}
+}
+
+// LockDuration eşik aşıldıktan sonraki her başarısız denemede kilit süresini ikiye katlar.
+func (c LoginLockoutConfig) LockDuration(failures, threshold int) time.Duration {
+	if threshold <= 0 || failures < threshold {
+		return 0
+	}
+	duration := c.BaseLockDuration
+	for i := threshold; i < failures && duration < c.MaxLockDuration; i++ {
+		duration *= 2
+	}
+	if duration > c.MaxLockDuration {
+		duration = c.MaxLockDuration
+	}
+	return duration
+}
+
+func GetRememberMeDuration() time.Duration {
+	return time.Duration(utils.GetEnvAsInt("REMEMBER_ME_DAYS", 30)) * 24 * time.Hour
+}
+
+func GetPasswordResetTTL() time.Duration {
+	return time.Duration(utils.GetEnvAsInt("PASSWORD_RESET_TTL_MINUTES", 60)) * time.Minute
+}
+
+func GetInvitationTTL() time.Duration {
+	return time.Duration(utils.GetEnvAsInt("INVITATION_TTL_HOURS", 72)) * time.Hour
+}
@@ -5,12 +5,15 @@ import (
 	"time"
 
 	"zatrano/models"
+	"zatrano/sessionstore"
 	"zatrano/utils"
 
+	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/session"
 )
 
 var Session *session.Store
+var sessionStorage fiber.Storage
 
 func InitSession() {
 	Session = createSessionStore()
@@ -31,13 +34,28 @@ func createSessionStore() *session.Store {
 
 	cookieSecure := utils.IsProduction()
 
-	store := session.New(session.Config{
+	config := session.Config{
 		CookieHTTPOnly: false,
 		CookieSecure:   cookieSecure,
 		Expiration:     time.Duration(sessionExpirationHours) * time.Hour,
 		KeyLookup:      "cookie:session_id",
 		CookieSameSite: "Lax",
-	})
+	}
+
+	storageDriver := utils.GetEnvWithDefault("SESSION_STORAGE", "memory")
+	switch storageDriver {
+	case "database":
+		gcIntervalMinutes := utils.GetEnvAsInt("SESSION_GC_INTERVAL_MINUTES", 10)
+		sessionStorage = sessionstore.NewDatabaseStorage(GetDB(), time.Duration(gcIntervalMinutes)*time.Minute)
+		config.Storage = sessionStorage
+		utils.SLog.Infof("Session verileri veritabanında saklanacak (temizlik aralığı: %d dakika).", gcIntervalMinutes)
+	case "memory":
+		utils.SLog.Info("Session verileri bellekte saklanacak.")
+	default:
+		utils.SLog.Warnf("Bilinmeyen SESSION_STORAGE değeri '%s', bellek kullanılacak.", storageDriver)
+	}
+
+	store := session.New(config)
 
 	utils.SLog.Infof("Cookie tabanlı session sistemi %d saatlik süreyle yapılandırıldı.", sessionExpirationHours)
 
@@ -46,6 +64,17 @@ func createSessionStore() *session.Store {
 	return store
 }
 
+func CloseSession() {
+	if sessionStorage == nil {
+		return
+	}
+	if err := sessionStorage.Close(); err != nil {
+		utils.SLog.Errorf("Session depolaması kapatılırken hata: %v", err)
+		return
+	}
+	utils.SLog.Info("Session depolaması kapatıldı.")
+}
+
 func registerGobTypes() {
 	gob.Register(models.UserType(""))
 	gob.Register(&models.User{})
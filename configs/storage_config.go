@@ -0,0 +1,90 @@
+package configs
+
+import (
+	"time"
+
+	"zatrano/storage"
+	"zatrano/utils"
+)
+
+// SignedFilesPath, yerel depodaki dosyaların imzalı adreslerle sunulduğu yoldur.
+const SignedFilesPath = "/files/signed"
+
+var FileStorage storage.Storage
+
+// InitStorage, yüklenen dosyaların saklanacağı arka ucu STORAGE_DRIVER ayarına göre hazırlar (local veya s3).
+func InitStorage() {
+	switch driver := utils.GetEnvWithDefault("STORAGE_DRIVER", "local"); driver {
+	case "s3":
+		s3, err := initS3Storage()
+		if err == nil {
+			FileStorage = s3
+			return
+		}
+		utils.SLog.Errorf("S3 dosya deposu başlatılamadı, yerel disk kullanılacak: %v", err)
+	case "local":
+	default:
+		utils.SLog.Warnf("Bilinmeyen STORAGE_DRIVER '%s', yerel disk kullanılacak.", driver)
+	}
+
+	dir := utils.GetEnvWithDefault("STORAGE_LOCAL_DIR", "./storage/files")
+	FileStorage = storage.NewLocalStorage(dir, SignedFilesPath, storageSigningKey())
+	utils.SLog.Infof("Yerel dosya deposu yapılandırıldı: %s", dir)
+}
+
+func initS3Storage() (storage.Storage, error) {
+	cfg := storage.S3Config{
+		Endpoint:     utils.GetEnvWithDefault("S3_ENDPOINT", "localhost:9000"),
+		AccessKey:    utils.GetEnvWithDefault("S3_ACCESS_KEY", ""),
+		SecretKey:    utils.GetEnvWithDefault("S3_SECRET_KEY", ""),
+		Bucket:       utils.GetEnvWithDefault("S3_BUCKET", "zatrano"),
+		Region:       utils.GetEnvWithDefault("S3_REGION", "us-east-1"),
+		UseSSL:       utils.GetEnvAsBool("S3_USE_SSL", false),
+		PathStyle:    utils.GetEnvAsBool("S3_PATH_STYLE", true),
+		CreateBucket: utils.GetEnvAsBool("S3_CREATE_BUCKET", false),
+	}
+	s3, err := storage.NewS3Storage(cfg)
+	if err != nil {
+		return nil, err
+	}
+	utils.SLog.Infof("S3 dosya deposu yapılandırıldı: %s/%s", cfg.Endpoint, cfg.Bucket)
+	return s3, nil
+}
+
+// storageSigningKey, yerel depodaki imzalı adreslerin anahtarıdır. Ayarlanmamışsa her açılışta rastgele
+// üretilir; bu durumda yeniden başlatma öncesi verilen adresler geçersiz olur.
+func storageSigningKey() []byte {
+	if key := utils.GetEnvWithDefault("STORAGE_SIGNING_KEY", ""); key != "" {
+		return []byte(key)
+	}
+	utils.SLog.Warn("STORAGE_SIGNING_KEY ayarlanmamış, imzalı dosya adresleri için geçici bir anahtar üretildi.")
+	key, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		utils.SLog.Fatalf("Dosya imza anahtarı üretilemedi: %v", err)
+	}
+	return []byte(key)
+}
+
+func GetStorage() storage.Storage {
+	if FileStorage == nil {
+		utils.SLog.Warn("Dosya deposu isteniyor ancak henüz başlatılmamış, şimdi başlatılıyor.")
+		InitStorage()
+	}
+	return FileStorage
+}
+
+// AvatarMaxBytes, yüklenebilecek profil resminin azami boyutudur.
+func AvatarMaxBytes() int64 {
+	return int64(utils.GetEnvAsInt("AVATAR_MAX_KB", 2048)) * 1024
+}
+
+// FileURLTTL, dosyalar için üretilen imzalı adreslerin geçerlilik süresidir.
+func FileURLTTL() time.Duration {
+	return time.Duration(utils.GetEnvAsInt("FILE_URL_TTL_MINUTES", 15)) * time.Minute
+}
+
+// FileDownloadRedirect, yetki denetiminden sonra dosyanın uygulama üzerinden akıtılması yerine imzalı
+// adrese yönlendirilip yönlendirilmeyeceğini belirtir (S3'te sunucu yükünü azaltır).
+func FileDownloadRedirect() bool {
+	return utils.GetEnvWithDefault("FILE_DOWNLOAD_MODE", "stream") == "redirect"
+}
@@ -0,0 +1,25 @@
+package configs
+
+import (
+	"zatrano/utils"
+)
+
+// UserListConfig, kullanıcı listesinin sayfalama ayarlarıdır.
+type UserListConfig struct {
+	// Paging, istekte "paging" parametresi verilmediğinde kullanılacak moddur: "offset" veya "cursor".
+	Paging string
+	// EstimateCountAbove, imleçli sayfalamada tablo bu satır sayısını aştığında toplamın COUNT(*) yerine
+	// Postgres istatistiklerinden tahmin edileceği eşiktir. 0 tahmini kapatır.
+	EstimateCountAbove int64
+}
+
+func GetUserListConfig() UserListConfig {
+	paging := utils.GetEnvWithDefault("USER_LIST_PAGINATION", utils.PagingOffset)
+	if paging != utils.PagingCursor {
+		paging = utils.PagingOffset
+	}
+	return UserListConfig{
+		Paging:             paging,
+		EstimateCountAbove: int64(utils.GetEnvAsInt("USER_LIST_ESTIMATE_COUNT_ABOVE", 50000)),
+	}
+}
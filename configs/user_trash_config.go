@@ -0,0 +1,25 @@
+package configs
+
+import (
+	"time"
+
+	"zatrano/utils"
+)
+
+// UserTrashConfig, silinen kullanıcıların çöp kutusunda ne kadar tutulacağını belirler.
+type UserTrashConfig struct {
+	// RetentionDays, silinen kullanıcıların kaç gün sonra kalıcı olarak silineceğidir; 0 otomatik silmeyi kapatır.
+	RetentionDays int
+	PurgeInterval time.Duration
+}
+
+func GetUserTrashConfig() UserTrashConfig {
+	return UserTrashConfig{
+		RetentionDays: utils.GetEnvAsInt("USER_TRASH_RETENTION_DAYS", 30),
+		PurgeInterval: time.Duration(utils.GetEnvAsInt("USER_TRASH_PURGE_INTERVAL_MINUTES", 60)) * time.Minute,
+	}
+}
+
+func (c UserTrashConfig) AutoPurgeEnabled() bool {
+	return c.RetentionDays > 0 && c.PurgeInterval > 0
+}
@@ -61,6 +61,27 @@ func Initialize(db *gorm.DB, migrate bool, seed bool) {
 }
 
 func RunMigrationsInOrder(db *gorm.DB) error {
+	utils.SLog.Info(" -> Role migrasyonları çalıştırılıyor...")
+	if err := migrations.MigrateRolesTables(db); err != nil {
+		utils.Log.Error("Role/Permission tabloları migrasyonu başarısız oldu", zap.Error(err))
+		return err
+	}
+	utils.SLog.Info(" -> Role migrasyonları tamamlandı.")
+
+	utils.SLog.Info(" -> Team migrasyonları çalıştırılıyor...")
+	if err := migrations.MigrateTeamsTable(db); err != nil {
+		utils.Log.Error("Teams tablosu migrasyonu başarısız oldu", zap.Error(err))
+		return err
+	}
+	utils.SLog.Info(" -> Team migrasyonları tamamlandı.")
+
+	utils.SLog.Info(" -> Arama eklentisi migrasyonları çalıştırılıyor...")
+	if err := migrations.MigrateSearchExtensions(db); err != nil {
+		utils.Log.Error("Arama eklentileri migrasyonu başarısız oldu", zap.Error(err))
+		return err
+	}
+	utils.SLog.Info(" -> Arama eklentisi migrasyonları tamamlandı.")
+
 	utils.SLog.Info(" -> User migrasyonları çalıştırılıyor...")
 	if err := migrations.MigrateUsersTable(db); err != nil {
 		utils.Log.Error("Users tablosu migrasyonu başarısız oldu", zap.Error(err))
@@ -68,6 +89,83 @@ func RunMigrationsInOrder(db *gorm.DB) error {
 	}
 	utils.SLog.Info(" -> User migrasyonları tamamlandı.")
 
+	utils.SLog.Info(" -> Kullanıcı arama indeksi migrasyonları çalıştırılıyor...")
+	if err := migrations.MigrateUserSearchIndexes(db); err != nil {
+		utils.Log.Error("Kullanıcı arama indeksleri migrasyonu başarısız oldu", zap.Error(err))
+		return err
+	}
+	utils.SLog.Info(" -> Kullanıcı arama indeksi migrasyonları tamamlandı.")
+
+	utils.SLog.Info(" -> Kullanıcı tiplerinden rollere geçiş çalıştırılıyor...")
+	if err := migrations.MigrateUserTypesToRoles(db); err != nil {
+		utils.Log.Error("Kullanıcı tiplerinden rollere geçiş başarısız oldu", zap.Error(err))
+		return err
+	}
+	utils.SLog.Info(" -> Kullanıcı tiplerinden rollere geçiş tamamlandı.")
+
+	utils.SLog.Info(" -> LoginAttempt migrasyonları çalıştırılıyor...")
+	if err := migrations.MigrateLoginAttemptsTable(db); err != nil {
+		utils.Log.Error("LoginIPAttempt tablosu migrasyonu başarısız oldu", zap.Error(err))
+		return err
+	}
+	utils.SLog.Info(" -> LoginAttempt migrasyonları tamamlandı.")
+
+	utils.SLog.Info(" -> TwoFactor migrasyonları çalıştırılıyor...")
+	if err := migrations.MigrateTwoFactorTables(db); err != nil {
+		utils.Log.Error("TwoFactor tabloları migrasyonu başarısız oldu", zap.Error(err))
+		return err
+	}
+	utils.SLog.Info(" -> TwoFactor migrasyonları tamamlandı.")
+
+	utils.SLog.Info(" -> PasswordReset migrasyonları çalıştırılıyor...")
+	if err := migrations.MigratePasswordResetTokensTable(db); err != nil {
+		utils.Log.Error("PasswordResetToken tablosu migrasyonu başarısız oldu", zap.Error(err))
+		return err
+	}
+	utils.SLog.Info(" -> PasswordReset migrasyonları tamamlandı.")
+
+	utils.SLog.Info(" -> UserInvitation migrasyonları çalıştırılıyor...")
+	if err := migrations.MigrateUserInvitationsTable(db); err != nil {
+		utils.Log.Error("UserInvitation tablosu migrasyonu başarısız oldu", zap.Error(err))
+		return err
+	}
+	utils.SLog.Info(" -> UserInvitation migrasyonları tamamlandı.")
+
+	utils.SLog.Info(" -> Session migrasyonları çalıştırılıyor...")
+	if err := migrations.MigrateSessionsTable(db); err != nil {
+		utils.Log.Error("Sessions tablosu migrasyonu başarısız oldu", zap.Error(err))
+		return err
+	}
+	utils.SLog.Info(" -> Session migrasyonları tamamlandı.")
+
+	utils.SLog.Info(" -> UserSession migrasyonları çalıştırılıyor...")
+	if err := migrations.MigrateUserSessionsTable(db); err != nil {
+		utils.Log.Error("UserSessions tablosu migrasyonu başarısız oldu", zap.Error(err))
+		return err
+	}
+	utils.SLog.Info(" -> UserSession migrasyonları tamamlandı.")
+
+	utils.SLog.Info(" -> SecurityEvent migrasyonları çalıştırılıyor...")
+	if err := migrations.MigrateSecurityEventsTable(db); err != nil {
+		utils.Log.Error("SecurityEvents tablosu migrasyonu başarısız oldu", zap.Error(err))
+		return err
+	}
+	utils.SLog.Info(" -> SecurityEvent migrasyonları tamamlandı.")
+
+	utils.SLog.Info(" -> RememberToken migrasyonları çalıştırılıyor...")
+	if err := migrations.MigrateRememberTokensTable(db); err != nil {
+		utils.Log.Error("RememberTokens tablosu migrasyonu başarısız oldu", zap.Error(err))
+		return err
+	}
+	utils.SLog.Info(" -> RememberToken migrasyonları tamamlandı.")
+
+	utils.SLog.Info(" -> PasswordHistory migrasyonları çalıştırılıyor...")
+	if err := migrations.MigratePasswordHistoriesTable(db); err != nil {
+		utils.Log.Error("PasswordHistories tablosu migrasyonu başarısız oldu", zap.Error(err))
+		return err
+	}
+	utils.SLog.Info(" -> PasswordHistory migrasyonları tamamlandı.")
+
 	utils.SLog.Info("Tüm migrasyonlar başarıyla çalıştırıldı.")
 	return nil
 }
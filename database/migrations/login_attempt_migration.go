@@ -0,0 +1,19 @@
+package migrations
+
+import (
+	"errors"
+	"zatrano/models"
+	"zatrano/utils"
+
+	"gorm.io/gorm"
+)
+
+func MigrateLoginAttemptsTable(db *gorm.DB) error {
+	utils.SLog.Info("LoginIPAttempt tablosu migrate ediliyor...")
+	if err := db.AutoMigrate(&models.LoginIPAttempt{}); err != nil {
+		return errors.New("LoginIPAttempt tablosu migrate edilemedi: " + err.Error())
+	}
+
+	utils.SLog.Info("LoginIPAttempt tablosu migrate işlemi tamamlandı.")
+	return nil
+}
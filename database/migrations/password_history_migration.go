@@ -0,0 +1,19 @@
+package migrations
+
+import (
+	"errors"
+	"zatrano/models"
+	"zatrano/utils"
+
+	"gorm.io/gorm"
+)
+
+func MigratePasswordHistoriesTable(db *gorm.DB) error {
+	utils.SLog.Info("PasswordHistory tablosu migrate ediliyor...")
+	if err := db.AutoMigrate(&models.PasswordHistory{}); err != nil {
+		return errors.New("PasswordHistory tablosu migrate edilemedi: " + err.Error())
+	}
+
+	utils.SLog.Info("PasswordHistory tablosu migrate işlemi tamamlandı.")
+	return nil
+}
@@ -0,0 +1,19 @@
+package migrations
+
+import (
+	"errors"
+	"zatrano/models"
+	"zatrano/utils"
+
+	"gorm.io/gorm"
+)
+
+func MigratePasswordResetTokensTable(db *gorm.DB) error {
+	utils.SLog.Info("PasswordResetToken tablosu migrate ediliyor...")
+	if err := db.AutoMigrate(&models.PasswordResetToken{}); err != nil {
+		return errors.New("PasswordResetToken tablosu migrate edilemedi: " + err.Error())
+	}
+
+	utils.SLog.Info("PasswordResetToken tablosu migrate işlemi tamamlandı.")
+	return nil
+}
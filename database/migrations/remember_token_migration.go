@@ -0,0 +1,19 @@
+package migrations
+
+import (
+	"errors"
+	"zatrano/models"
+	"zatrano/utils"
+
+	"gorm.io/gorm"
+)
+
+func MigrateRememberTokensTable(db *gorm.DB) error {
+	utils.SLog.Info("RememberToken tablosu migrate ediliyor...")
+	if err := db.AutoMigrate(&models.RememberToken{}); err != nil {
+		return errors.New("RememberToken tablosu migrate edilemedi: " + err.Error())
+	}
+
+	utils.SLog.Info("RememberToken tablosu migrate işlemi tamamlandı.")
+	return nil
+}
@@ -0,0 +1,81 @@
+package migrations
+
+import (
+	"errors"
+	"zatrano/models"
+	"zatrano/utils"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// MigrateRolesTables, users tablosundaki user_roles ilişkisi bu tablolara bağlı olduğundan User migrasyonundan önce çalışmalıdır.
+func MigrateRolesTables(db *gorm.DB) error {
+	utils.SLog.Info("Role ve Permission tabloları migrate ediliyor...")
+	if err := db.AutoMigrate(&models.Permission{}, &models.Role{}); err != nil {
+		return errors.New("Role/Permission tabloları migrate edilemedi: " + err.Error())
+	}
+
+	utils.SLog.Info("Role ve Permission tabloları migrate işlemi tamamlandı.")
+	return nil
+}
+
+// MigrateUserTypesToRoles, izinleri ve yerleşik rolleri oluşturur; henüz rolü olmayan kullanıcılara
+// tiplerine karşılık gelen yerleşik rolü atar.
+func MigrateUserTypesToRoles(db *gorm.DB) error {
+	utils.SLog.Info("İzinler ve yerleşik roller oluşturuluyor...")
+
+	permissions := models.PermissionDefinitions()
+	err := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"name"}),
+	}).Create(&permissions).Error
+	if err != nil {
+		return errors.New("İzinler oluşturulamadı: " + err.Error())
+	}
+
+	for _, builtIn := range models.BuiltInRoles() {
+		var role models.Role
+		result := db.Where("slug = ?", builtIn.Slug).First(&role)
+		created := false
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			role = models.Role{Name: builtIn.Name, Slug: builtIn.Slug, Description: builtIn.Description, BuiltIn: true}
+			if err := db.Create(&role).Error; err != nil {
+				return errors.New(builtIn.Slug + " rolü oluşturulamadı: " + err.Error())
+			}
+			created = true
+		} else if result.Error != nil {
+			return errors.New(builtIn.Slug + " rolü okunamadı: " + result.Error.Error())
+		} else if !role.BuiltIn {
+			if err := db.Model(&role).Update("built_in", true).Error; err != nil {
+				return errors.New(builtIn.Slug + " rolü güncellenemedi: " + err.Error())
+			}
+		}
+
+		// Sistem rolü her zaman tüm izinlere sahiptir; diğer yerleşik rollerin izinleri yalnızca ilk oluşturulurken atanır.
+		if !created && builtIn.Slug != models.RoleSlugSystem {
+			continue
+		}
+		var rolePermissions []models.Permission
+		if err := db.Where("key IN ?", builtIn.Permissions).Find(&rolePermissions).Error; err != nil {
+			return errors.New(builtIn.Slug + " rolünün izinleri okunamadı: " + err.Error())
+		}
+		if err := db.Model(&role).Association("Permissions").Replace(rolePermissions); err != nil {
+			return errors.New(builtIn.Slug + " rolünün izinleri atanamadı: " + err.Error())
+		}
+	}
+
+	utils.SLog.Info("Rolü olmayan kullanıcılar tiplerine göre yerleşik rollere taşınıyor...")
+	result := db.Exec(`
+		INSERT INTO user_roles (user_id, role_id)
+		SELECT u.id, r.id
+		FROM users u
+		JOIN roles r ON r.slug = u.type::text
+		WHERE NOT EXISTS (SELECT 1 FROM user_roles ur WHERE ur.user_id = u.id)`)
+	if result.Error != nil {
+		return errors.New("Kullanıcılara rol atanamadı: " + result.Error.Error())
+	}
+	utils.SLog.Infof("%d kullanıcıya yerleşik rol atandı.", result.RowsAffected)
+
+	return nil
+}
@@ -0,0 +1,37 @@
+package migrations
+
+import (
+	"errors"
+	"zatrano/search"
+	"zatrano/utils"
+
+	"gorm.io/gorm"
+)
+
+// MigrateSearchExtensions, pg_trgm ve unaccent eklentilerini ve search_normalize fonksiyonunu oluşturur.
+// Eklenti oluşturmak için veritabanı kullanıcısının CREATE yetkisi olmalıdır.
+func MigrateSearchExtensions(db *gorm.DB) error {
+	utils.SLog.Info("Arama eklentileri ve normalizasyon fonksiyonu oluşturuluyor...")
+	for _, statement := range search.InstallSQL {
+		if err := db.Exec(statement).Error; err != nil {
+			return errors.New("arama eklentileri oluşturulamadı: " + err.Error())
+		}
+	}
+
+	utils.SLog.Info("Arama eklentileri hazır.")
+	return nil
+}
+
+// MigrateUserSearchIndexes, kullanıcı aramasında kullanılan ad ve hesap sütunları için trigram indekslerini
+// oluşturur. Users tablosu ve MigrateSearchExtensions'tan sonra çalışmalıdır.
+func MigrateUserSearchIndexes(db *gorm.DB) error {
+	utils.SLog.Info("Kullanıcı arama indeksleri oluşturuluyor...")
+	for _, column := range []string{"name", "account"} {
+		if err := db.Exec(search.TrigramIndexSQL("users", column)).Error; err != nil {
+			return errors.New("users." + column + " arama indeksi oluşturulamadı: " + err.Error())
+		}
+	}
+
+	utils.SLog.Info("Kullanıcı arama indeksleri oluşturuldu.")
+	return nil
+}
@@ -0,0 +1,19 @@
+package migrations
+
+import (
+	"errors"
+	"zatrano/models"
+	"zatrano/utils"
+
+	"gorm.io/gorm"
+)
+
+func MigrateSecurityEventsTable(db *gorm.DB) error {
+	utils.SLog.Info("SecurityEvent tablosu migrate ediliyor...")
+	if err := db.AutoMigrate(&models.SecurityEvent{}); err != nil {
+		return errors.New("SecurityEvent tablosu migrate edilemedi: " + err.Error())
+	}
+
+	utils.SLog.Info("SecurityEvent tablosu migrate işlemi tamamlandı.")
+	return nil
+}
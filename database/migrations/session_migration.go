@@ -0,0 +1,19 @@
+package migrations
+
+import (
+	"errors"
+	"zatrano/models"
+	"zatrano/utils"
+
+	"gorm.io/gorm"
+)
+
+func MigrateSessionsTable(db *gorm.DB) error {
+	utils.SLog.Info("Session tablosu migrate ediliyor...")
+	if err := db.AutoMigrate(&models.Session{}); err != nil {
+		return errors.New("Session tablosu migrate edilemedi: " + err.Error())
+	}
+
+	utils.SLog.Info("Session tablosu migrate işlemi tamamlandı.")
+	return nil
+}
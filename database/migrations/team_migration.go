@@ -0,0 +1,20 @@
+package migrations
+
+import (
+	"errors"
+	"zatrano/models"
+	"zatrano/utils"
+
+	"gorm.io/gorm"
+)
+
+// MigrateTeamsTable, users.team_id yabancı anahtarı bu tabloya bağlı olduğundan User migrasyonundan önce çalışmalıdır.
+func MigrateTeamsTable(db *gorm.DB) error {
+	utils.SLog.Info("Team tablosu migrate ediliyor...")
+	if err := db.AutoMigrate(&models.Team{}); err != nil {
+		return errors.New("Team tablosu migrate edilemedi: " + err.Error())
+	}
+
+	utils.SLog.Info("Team tablosu migrate işlemi tamamlandı.")
+	return nil
+}
@@ -0,0 +1,22 @@
+package migrations
+
+import (
+	"errors"
+	"zatrano/models"
+	"zatrano/utils"
+
+	"gorm.io/gorm"
+)
+
+func MigrateTwoFactorTables(db *gorm.DB) error {
+	utils.SLog.Info("RecoveryCode ve UserTypePolicy tabloları migrate ediliyor...")
+	if err := db.AutoMigrate(&models.RecoveryCode{}); err != nil {
+		return errors.New("RecoveryCode tablosu migrate edilemedi: " + err.Error())
+	}
+	if err := db.AutoMigrate(&models.UserTypePolicy{}); err != nil {
+		return errors.New("UserTypePolicy tablosu migrate edilemedi: " + err.Error())
+	}
+
+	utils.SLog.Info("RecoveryCode ve UserTypePolicy tabloları migrate işlemi tamamlandı.")
+	return nil
+}
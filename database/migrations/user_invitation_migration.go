@@ -0,0 +1,19 @@
+package migrations
+
+import (
+	"errors"
+	"zatrano/models"
+	"zatrano/utils"
+
+	"gorm.io/gorm"
+)
+
+func MigrateUserInvitationsTable(db *gorm.DB) error {
+	utils.SLog.Info("UserInvitation tablosu migrate ediliyor...")
+	if err := db.AutoMigrate(&models.UserInvitation{}); err != nil {
+		return errors.New("UserInvitation tablosu migrate edilemedi: " + err.Error())
+	}
+
+	utils.SLog.Info("UserInvitation tablosu migrate işlemi tamamlandı.")
+	return nil
+}
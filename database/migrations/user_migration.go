@@ -30,11 +30,27 @@ func MigrateUsersTable(db *gorm.DB) error {
 	}
 	utils.SLog.Info("user_type enum başarıyla oluşturuldu.")
 
+	// Hesap adı benzersizliği yalnızca silinmemiş kayıtlar için geçerlidir; eski tam benzersizlik kısıtı
+	// silinen kullanıcıların hesap adının yeniden kullanılmasını engellediği için kaldırılır.
+	for _, constraint := range []string{"users_account_key", "uni_users_account"} {
+		if err := db.Exec("ALTER TABLE IF EXISTS users DROP CONSTRAINT IF EXISTS " + constraint).Error; err != nil {
+			return errors.New("users.account benzersizlik kısıtı kaldırılamadı: " + err.Error())
+		}
+	}
+
 	utils.SLog.Info("User tablosu migrate ediliyor...")
 	if err := db.AutoMigrate(&models.User{}); err != nil {
 		return errors.New("User tablosu migrate edilemedi: " + err.Error())
 	}
 
+	// Korumalı kullanıcı önceden ID'si 1 olan kullanıcıydı; bayraktan önce kurulmuş veritabanlarında
+	// aynı kullanıcı korumalı olarak işaretlenir.
+	backfill := `UPDATE users SET protected = true
+		WHERE id = 1 AND NOT EXISTS (SELECT 1 FROM users WHERE protected)`
+	if err := db.Exec(backfill).Error; err != nil {
+		return errors.New("korumalı sistem kullanıcısı işaretlenemedi: " + err.Error())
+	}
+
 	utils.SLog.Info("User tablosu migrate işlemi tamamlandı.")
 	return nil
 }
@@ -0,0 +1,19 @@
+package migrations
+
+import (
+	"errors"
+	"zatrano/models"
+	"zatrano/utils"
+
+	"gorm.io/gorm"
+)
+
+func MigrateUserSessionsTable(db *gorm.DB) error {
+	utils.SLog.Info("UserSession tablosu migrate ediliyor...")
+	if err := db.AutoMigrate(&models.UserSession{}); err != nil {
+		return errors.New("UserSession tablosu migrate edilemedi: " + err.Error())
+	}
+
+	utils.SLog.Info("UserSession tablosu migrate işlemi tamamlandı.")
+	return nil
+}
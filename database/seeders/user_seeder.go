@@ -21,11 +21,14 @@ func SeedSystemUser(db *gorm.DB) error {
 	systemUserConfig := GetSystemUserConfig()
 
 	userToSeed := models.User{
-		Name:     systemUserConfig.Name,
-		Account:  systemUserConfig.Account,
-		Type:     systemUserConfig.Type,
-		Password: systemUserConfig.Password,
-		Status:   true,
+		Name:      systemUserConfig.Name,
+		Account:   systemUserConfig.Account,
+		Type:      systemUserConfig.Type,
+		Password:  systemUserConfig.Password,
+		Status:    true,
+		Protected: true,
+		// Seed şifresi kaynak kodda yer aldığından ilk girişte değiştirilmesi zorunludur.
+		MustChangePassword: true,
 	}
 
 	var existingUser models.User
@@ -45,6 +48,19 @@ func SeedSystemUser(db *gorm.DB) error {
 			updateFields["status"] = true
 			needsUpdate = true
 		}
+		if !existingUser.Protected {
+			updateFields["protected"] = true
+			needsUpdate = true
+		}
+		// Önceki sürümlerde oluşturulan kullanıcı hâlâ kaynak koddaki seed şifresini kullanıyorsa değiştirmeye zorlanır.
+		if !existingUser.MustChangePassword && existingUser.CheckPassword(systemUserConfig.Password) == nil {
+			updateFields["must_change_password"] = true
+			needsUpdate = true
+		}
+
+		if err := ensureBuiltInRole(db, &existingUser); err != nil {
+			return err
+		}
 
 		if needsUpdate {
 			utils.SLog.Infof("Mevcut sistem kullanıcısı '%s' güncelleniyor...", userToSeed.Account)
@@ -80,6 +96,34 @@ func SeedSystemUser(db *gorm.DB) error {
 		return err
 	}
 
+	if err := ensureBuiltInRole(db, &userToSeed); err != nil {
+		return err
+	}
+
 	utils.SLog.Infof("Sistem kullanıcısı '%s' başarıyla oluşturuldu.", userToSeed.Account)
 	return nil
 }
+
+// ensureBuiltInRole, rolü olmayan kullanıcıya tipine karşılık gelen yerleşik rolü atar.
+func ensureBuiltInRole(db *gorm.DB, user *models.User) error {
+	roleCount := db.Model(user).Association("Roles").Count()
+	if roleCount > 0 {
+		return nil
+	}
+
+	var role models.Role
+	if err := db.Where("slug = ?", models.DefaultRoleSlugFor(user.Type)).First(&role).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			utils.Log.Warn("Yerleşik rol bulunamadı, önce migrasyonları çalıştırın", zap.String("account", user.Account))
+			return nil
+		}
+		return err
+	}
+
+	if err := db.Model(user).Association("Roles").Append(&role); err != nil {
+		utils.Log.Error("Sistem kullanıcısına rol atanamadı", zap.String("account", user.Account), zap.Error(err))
+		return err
+	}
+	utils.SLog.Infof("'%s' kullanıcısına '%s' rolü atandı.", user.Account, role.Name)
+	return nil
+}
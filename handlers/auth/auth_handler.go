@@ -1,7 +1,13 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
 	"zatrano/models"
+	"zatrano/passwordpolicy"
 	"zatrano/services"
 	"zatrano/utils"
 
@@ -10,13 +16,31 @@ import (
 )
 
 type AuthHandler struct {
-	service services.IAuthService
+	service              services.IAuthService
+	twoFactorService     services.ITwoFactorService
+	passwordResetService services.IPasswordResetService
+	sessionService       services.ISessionService
+	securityEventService services.ISecurityEventService
+	rememberMeService    services.IRememberMeService
+	userService          services.IUserService
+	invitationService    services.IUserInvitationService
 }
 
 func NewAuthHandler() *AuthHandler {
-	return &AuthHandler{service: services.NewAuthService()}
+	return &AuthHandler{
+		service:              services.NewAuthService(),
+		twoFactorService:     services.NewTwoFactorService(),
+		passwordResetService: services.NewPasswordResetService(),
+		sessionService:       services.NewSessionService(),
+		securityEventService: services.NewSecurityEventService(),
+		rememberMeService:    services.NewRememberMeService(),
+		userService:          services.NewUserService(),
+		invitationService:    services.NewUserInvitationService(),
+	}
 }
 
+const invalidCredentialsMessage = "Kullanıcı adı veya şifre hatalı. Çok sayıda başarısız denemeden sonra hesaplar bir süre için kilitlenir."
+
 func (h *AuthHandler) ShowLogin(c *fiber.Ctx) error {
 	flashData, err := utils.GetFlashMessages(c)
 	if err != nil {
@@ -35,6 +59,7 @@ func (h *AuthHandler) Login(c *fiber.Ctx) error {
 	var request struct {
 		Account  string `form:"account"`
 		Password string `form:"password"`
+		Remember string `form:"remember"`
 	}
 
 	if err := c.BodyParser(&request); err != nil {
@@ -48,25 +73,59 @@ func (h *AuthHandler) Login(c *fiber.Ctx) error {
 		return c.Redirect("/auth/login", fiber.StatusSeeOther)
 	}
 
-	user, err := h.service.Authenticate(request.Account, request.Password)
+	user, err := h.service.Authenticate(request.Account, request.Password, c.IP())
 	if err != nil {
-		var errMsg string
-		switch err {
-		case services.ErrInvalidCredentials:
-			errMsg = "Kullanıcı adı veya şifre hatalı."
-		case services.ErrUserInactive:
+		var errMsg, reason string
+		var lockErr *services.LoginLockedError
+		switch {
+		case errors.As(err, &lockErr):
+			reason = models.FailureIPLocked
+			minutes := int(math.Ceil(time.Until(lockErr.Until).Minutes()))
+			if minutes < 1 {
+				minutes = 1
+			}
+			errMsg = fmt.Sprintf("Çok sayıda başarısız giriş denemesi yapıldı. Lütfen %d dakika sonra tekrar deneyin.", minutes)
+		case err == services.ErrInvalidCredentials, err == services.ErrAccountLocked:
+			// Kilitli hesap ile var olmayan hesap aynı mesajı alır; aksi halde kilit yanıtı hesabın varlığını ele verir.
+			reason = models.FailureInvalidCredentials
+			if err == services.ErrAccountLocked {
+				reason = models.FailureAccountLocked
+			}
+			errMsg = invalidCredentialsMessage
+		case err == services.ErrUserInactive:
+			reason = models.FailureUserInactive
 			errMsg = "Hesabınız aktif değil. Lütfen yöneticinizle iletişime geçin."
+		case err == services.ErrUserPending:
+			reason = models.FailureInvitationPending
+			errMsg = "Hesabınız henüz etkinleştirilmedi. Lütfen davet e-postanızdaki bağlantıyla şifrenizi belirleyin."
 		default:
+			reason = models.FailureInternalError
 			errMsg = "Giriş işlemi sırasında bir sorun oluştu. Lütfen tekrar deneyin."
 			utils.Log.Error("Kimlik doğrulama servisinde beklenmeyen hata",
 				zap.String("account", request.Account),
 				zap.Error(err),
 			)
 		}
+		var userID *uint
+		if user != nil {
+			userID = &user.ID
+		}
+		h.recordSecurityEvent(c, userID, request.Account, models.EventLoginFailed, reason)
 		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, errMsg)
 		return c.Redirect("/auth/login", fiber.StatusSeeOther)
 	}
 
+	remember := request.Remember == "true"
+	if user.TwoFactorEnabled {
+		return h.startTwoFactorChallenge(c, user, remember)
+	}
+
+	return h.completeLogin(c, user, remember)
+}
+
+// completeLogin, kimliği doğrulanmış kullanıcı için oturumu açar ve tipine göre yönlendirir.
+// remember true ise cihaz için kalıcı bir "beni hatırla" anahtarı da verilir.
+func (h *AuthHandler) completeLogin(c *fiber.Ctx, user *models.User, remember bool) error {
 	sess, sessionErr := utils.SessionStart(c)
 	if sessionErr != nil {
 		utils.Log.Error("Oturum başlatılamadı (Login)",
@@ -78,10 +137,20 @@ func (h *AuthHandler) Login(c *fiber.Ctx) error {
 		return c.Redirect("/auth/login", fiber.StatusSeeOther)
 	}
 
-	sess.Set("user_id", user.ID)
-	sess.Set("user_type", string(user.Type))
-	sess.Set("user_status", user.Status)
-	sess.Set("user_name", user.Name)
+	if err := utils.EstablishUserSession(sess, user); err != nil {
+		utils.Log.Error("Oturum kimliği yenilenemedi (Login)", zap.Uint("user_id", user.ID), zap.Error(err))
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Oturum başlatılamadı. Lütfen tekrar deneyin.")
+		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	}
+	sess.Delete("pending_2fa_user_id")
+	sess.Delete("pending_2fa_started_at")
+	sess.Delete("pending_2fa_attempts")
+	sess.Delete("pending_2fa_remember")
+
+	setupRequired := !user.TwoFactorEnabled && h.twoFactorService.IsRequiredFor(user.Type)
+	if setupRequired {
+		sess.Set("two_factor_setup_required", true)
+	}
 
 	if saveErr := sess.Save(); saveErr != nil {
 		utils.Log.Error("Oturum kaydedilemedi (Login)",
@@ -93,6 +162,25 @@ func (h *AuthHandler) Login(c *fiber.Ctx) error {
 		return c.Redirect("/auth/login", fiber.StatusSeeOther)
 	}
 
+	var rememberSelector string
+	if remember {
+		remembered, err := h.rememberMeService.Issue(user.ID, c.IP(), c.Get(fiber.HeaderUserAgent))
+		if err != nil {
+			utils.Log.Warn("Beni hatırla anahtarı verilemedi, normal oturumla devam ediliyor", zap.Uint("user_id", user.ID), zap.Error(err))
+		} else {
+			rememberSelector = remembered.Selector
+			utils.SetRememberMeCookie(c, remembered.CookieValue, remembered.ExpiresAt)
+		}
+	}
+
+	if err := h.sessionService.Register(user.ID, sess.ID(), c.IP(), c.Get(fiber.HeaderUserAgent), rememberSelector); err != nil {
+		_ = sess.Destroy()
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Oturum kaydedilemedi. Lütfen tekrar deneyin.")
+		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	}
+
+	h.recordSecurityEvent(c, &user.ID, user.Account, models.EventLoginSucceeded, "")
+
 	var redirectURL string
 	switch user.Type {
 	case models.Panel:
@@ -110,6 +198,11 @@ func (h *AuthHandler) Login(c *fiber.Ctx) error {
 		return c.Redirect("/auth/login", fiber.StatusSeeOther)
 	}
 
+	if setupRequired {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Hesabınız için iki adımlı doğrulama zorunludur. Lütfen kurulumu tamamlayın.")
+		return c.Redirect("/auth/2fa/setup", fiber.StatusFound)
+	}
+
 	_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, "Başarıyla giriş yapıldı.")
 	return c.Redirect(redirectURL, fiber.StatusFound)
 }
@@ -120,62 +213,37 @@ func (h *AuthHandler) Profile(c *fiber.Ctx) error {
 		utils.Log.Warn("Profil: Flash mesajları alınamadı", zap.Error(flashErr))
 	}
 
-	userID, ok := c.Locals("userID").(uint)
+	user, ok := utils.CurrentUser(c)
 	if !ok {
-		utils.SLog.Debug("Profil: UserID locals'ta bulunamadı, session kontrol ediliyor.")
-		sess, sessionErr := utils.SessionStart(c)
-		if sessionErr != nil {
-			utils.Log.Error("Profil: Oturum başlatılamadı (locals'ta ID yok)", zap.Error(sessionErr))
-			_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Oturum hatası, lütfen tekrar giriş yapın.")
-			return c.Redirect("/auth/login", fiber.StatusSeeOther)
-		}
-		userIDValue := sess.Get("user_id")
-		switch v := userIDValue.(type) {
-		case uint:
-			userID = v
-			ok = true
-		case int:
-			userID = uint(v)
-			ok = true
-		case float64:
-			userID = uint(v)
-			ok = true
-		default:
-			ok = false
-		}
-		if !ok {
-			utils.Log.Warn("Profil: Session'da geçersiz veya eksik user_id", zap.Any("value", userIDValue))
-			_ = sess.Destroy()
-			_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Geçersiz oturum bilgisi, lütfen tekrar giriş yapın.")
-			return c.Redirect("/auth/login", fiber.StatusSeeOther)
-		}
-		utils.SLog.Debugf("Profil: UserID session'dan alındı: %d", userID)
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Geçersiz oturum bilgisi, lütfen tekrar giriş yapın.")
+		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	}
+
+	var recoveryCodesLeft int64
+	if user.TwoFactorEnabled {
+		recoveryCodesLeft = h.twoFactorService.RemainingRecoveryCodes(user.ID)
 	}
 
-	user, err := h.service.GetUserProfile(userID)
+	sessions := h.activeSessions(c, user.ID)
+
+	recentEvents, err := h.securityEventService.GetRecentForUser(user.ID)
 	if err != nil {
-		var errMsg string
-		if err == services.ErrUserNotFound {
-			errMsg = "Profil bilgileri bulunamadı, lütfen tekrar giriş yapın."
-			utils.Log.Warn("Profil: Kullanıcı bulunamadı", zap.Uint("user_id", userID))
-			sess, _ := utils.SessionStart(c)
-			if sess != nil {
-				_ = sess.Destroy()
-			}
-		} else {
-			errMsg = "Profil bilgileri alınırken bir hata oluştu."
-			utils.Log.Error("Profil: Kullanıcı profili alınırken hata", zap.Uint("user_id", userID), zap.Error(err))
-		}
-		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, errMsg)
-		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+		recentEvents = nil
 	}
 
 	return c.Render("auth/auth_profile", fiber.Map{
-		"Title":     "Profilim",
-		"User":      user,
-		"CsrfToken": c.Locals("csrf"),
-		"Success":   flashData.Success,
-		"Error":     flashData.Error,
+		"Title":             "Profilim",
+		"User":              user,
+		"TwoFactorRequired": h.twoFactorService.IsRequiredFor(user.Type),
+		"RecoveryCodesLeft": recoveryCodesLeft,
+		"Sessions":          sessions,
+		"RememberedDevices": h.rememberMeService.CountActive(user.ID),
+		"SecurityEvents":    recentEvents,
+		"PasswordRules":     passwordpolicy.For(string(user.Type)).Requirements(),
+		"AvatarMaxKB":       services.AvatarMaxKB(),
+		"CsrfToken":         c.Locals("csrf"),
+		"Success":           flashData.Success,
+		"Error":             flashData.Error,
 	}, "layouts/auth_layout")
 }
 
@@ -187,6 +255,15 @@ func (h *AuthHandler) Logout(c *fiber.Ctx) error {
 		return c.Redirect("/auth/login", fiber.StatusFound)
 	}
 
+	if userID, ok := utils.CurrentUserID(c); ok {
+		h.recordSecurityEvent(c, &userID, "", models.EventLogout, "")
+	}
+	_ = h.sessionService.Remove(sess.ID())
+	if cookie := c.Cookies(utils.RememberMeCookieName); cookie != "" {
+		_ = h.rememberMeService.Forget(cookie)
+		utils.ClearRememberMeCookie(c)
+	}
+
 	flashMsg := "Başarıyla çıkış yapıldı."
 	if destroyErr := sess.Destroy(); destroyErr != nil {
 		utils.Log.Error("Çıkış: Oturum yok edilemedi", zap.Error(destroyErr))
@@ -198,18 +275,18 @@ func (h *AuthHandler) Logout(c *fiber.Ctx) error {
 }
 
 func (h *AuthHandler) UpdatePassword(c *fiber.Ctx) error {
-	userID, ok := c.Locals("userID").(uint)
-
+	userID, ok := utils.CurrentUserID(c)
 	if !ok {
-		utils.Log.Warn("Parola Güncelleme: Session'da geçersiz veya eksik user_id", zap.Any("value", c.Locals("userID")))
-		sess, _ := utils.SessionStart(c)
-		if sess != nil {
-			_ = sess.Destroy()
-		}
 		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Geçersiz oturum bilgisi, lütfen tekrar giriş yapın.")
 		return c.Redirect("/auth/login", fiber.StatusSeeOther)
 	}
 
+	return h.changePassword(c, userID, "/auth/profile")
+}
+
+// changePassword, formdan gelen şifre değişikliğini uygular; hata durumunda formPath'e döner,
+// başarıda tüm oturumları kapatıp yeniden giriş ister.
+func (h *AuthHandler) changePassword(c *fiber.Ctx, userID uint, formPath string) error {
 	var request struct {
 		CurrentPassword string `form:"current_password"`
 		NewPassword     string `form:"new_password"`
@@ -218,29 +295,27 @@ func (h *AuthHandler) UpdatePassword(c *fiber.Ctx) error {
 	if err := c.BodyParser(&request); err != nil {
 		utils.SLog.Warnf("Parola güncelleme isteği ayrıştırılamadı: %v", err)
 		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Lütfen tüm şifre alanlarını doldurun.")
-		return c.Redirect("/auth/profile", fiber.StatusSeeOther)
+		return c.Redirect(formPath, fiber.StatusSeeOther)
 	}
 	if request.CurrentPassword == "" || request.NewPassword == "" || request.ConfirmPassword == "" {
 		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Lütfen tüm şifre alanlarını doldurun.")
-		return c.Redirect("/auth/profile", fiber.StatusSeeOther)
+		return c.Redirect(formPath, fiber.StatusSeeOther)
 	}
 	if request.NewPassword != request.ConfirmPassword {
 		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Yeni şifreler uyuşmuyor.")
-		return c.Redirect("/auth/profile", fiber.StatusSeeOther)
+		return c.Redirect(formPath, fiber.StatusSeeOther)
 	}
 
 	err := h.service.UpdatePassword(userID, request.CurrentPassword, request.NewPassword)
 	if err != nil {
 		var errMsg string
 		flashKey := utils.FlashErrorKey
-		redirectTarget := "/auth/profile"
+		redirectTarget := formPath
 		logoutUser := false
 
 		switch err {
 		case services.ErrCurrentPasswordIncorrect:
 			errMsg = "Mevcut şifreniz hatalı."
-		case services.ErrPasswordTooShort:
-			errMsg = err.Error()
 		case services.ErrPasswordSameAsOld:
 			errMsg = err.Error()
 		case services.ErrUserNotFound:
@@ -249,6 +324,11 @@ func (h *AuthHandler) UpdatePassword(c *fiber.Ctx) error {
 			redirectTarget = "/auth/login"
 			utils.Log.Warn("Parola Güncelleme: Kullanıcı bulunamadı (servis hatası)", zap.Uint("user_id", userID))
 		default:
+			var policyErr *passwordpolicy.PolicyError
+			if errors.As(err, &policyErr) {
+				errMsg = policyErr.Error()
+				break
+			}
 			errMsg = "Şifre güncellenirken bilinmeyen bir hata oluştu."
 			utils.Log.Error("Parola güncelleme servisinde beklenmeyen hata", zap.Uint("user_id", userID), zap.Error(err))
 		}
@@ -264,6 +344,12 @@ func (h *AuthHandler) UpdatePassword(c *fiber.Ctx) error {
 		return c.Redirect(redirectTarget, fiber.StatusSeeOther)
 	}
 
+	h.recordSecurityEvent(c, &userID, "", models.EventPasswordChanged, "")
+
+	if _, err := h.sessionService.RevokeAll(userID); err != nil {
+		utils.Log.Warn("Parola güncellendi ancak diğer oturumlar sonlandırılamadı", zap.Uint("user_id", userID), zap.Error(err))
+	}
+
 	flashMsg := "Şifre başarıyla güncellendi. Lütfen yeni şifrenizle tekrar giriş yapın."
 	sess, sessionErr := utils.SessionStart(c)
 	if sess != nil {
@@ -281,3 +367,16 @@ func (h *AuthHandler) UpdatePassword(c *fiber.Ctx) error {
 	_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, flashMsg)
 	return c.Redirect("/auth/login", fiber.StatusFound)
 }
+
+// recordSecurityEvent, isteğin IP ve tarayıcı bilgisiyle birlikte bir güvenlik olayı kaydeder.
+func (h *AuthHandler) recordSecurityEvent(c *fiber.Ctx, userID *uint, account string, eventType models.SecurityEventType, failureReason string) {
+	h.securityEventService.Record(&models.SecurityEvent{
+		UserID:        userID,
+		Account:       account,
+		Type:          eventType,
+		Success:       failureReason == "",
+		FailureReason: failureReason,
+		IP:            c.IP(),
+		UserAgent:     c.Get(fiber.HeaderUserAgent),
+	})
+}
@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"errors"
+
+	"zatrano/models"
+	"zatrano/passwordpolicy"
+	"zatrano/services"
+	"zatrano/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+const invitationInvalidMessage = "Davet bağlantısı geçersiz veya süresi dolmuş. Lütfen yöneticinizden yeni bir davet isteyin."
+
+func (h *AuthHandler) ShowAcceptInvite(c *fiber.Ctx) error {
+	token := c.Params("token")
+	user, err := h.invitationService.ValidateToken(token)
+	if err != nil {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, invitationInvalidMessage)
+		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	}
+
+	flashData, err := utils.GetFlashMessages(c)
+	if err != nil {
+		utils.Log.Warn("Davet kabul sayfası: Flash mesajları alınamadı", zap.Error(err))
+	}
+
+	return c.Render("auth/auth_accept_invite", fiber.Map{
+		"Title":     "Hesabınızı Etkinleştirin",
+		"CsrfToken": c.Locals("csrf"),
+		"Token":     token,
+		"Name":      user.Name,
+		"Account":   user.Account,
+		"Success":   flashData.Success,
+		"Error":     flashData.Error,
+	}, "layouts/auth_layout")
+}
+
+func (h *AuthHandler) AcceptInvite(c *fiber.Ctx) error {
+	token := c.Params("token")
+	invitePath := "/auth/accept-invite/" + token
+
+	var request struct {
+		NewPassword     string `form:"new_password"`
+		ConfirmPassword string `form:"confirm_password"`
+	}
+	if err := c.BodyParser(&request); err != nil || request.NewPassword == "" || request.ConfirmPassword == "" {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Lütfen tüm şifre alanlarını doldurun.")
+		return c.Redirect(invitePath, fiber.StatusSeeOther)
+	}
+	if request.NewPassword != request.ConfirmPassword {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Şifreler uyuşmuyor.")
+		return c.Redirect(invitePath, fiber.StatusSeeOther)
+	}
+
+	userID, err := h.invitationService.Accept(token, request.NewPassword)
+	if err != nil {
+		switch err {
+		case services.ErrInvitationInvalid:
+			_ = utils.SetFlashMessage(c, utils.FlashErrorKey, invitationInvalidMessage)
+			return c.Redirect("/auth/login", fiber.StatusSeeOther)
+		default:
+			var policyErr *passwordpolicy.PolicyError
+			if errors.As(err, &policyErr) {
+				_ = utils.SetFlashMessage(c, utils.FlashErrorKey, policyErr.Error())
+				break
+			}
+			utils.Log.Error("Davet kabul servisinde beklenmeyen hata", zap.Error(err))
+			_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Şifreniz kaydedilirken bir hata oluştu. Lütfen tekrar deneyin.")
+		}
+		return c.Redirect(invitePath, fiber.StatusSeeOther)
+	}
+
+	h.recordSecurityEvent(c, &userID, "", models.EventInvitationAccepted, "")
+
+	_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, "Hesabınız etkinleştirildi. Belirlediğiniz şifreyle giriş yapabilirsiniz.")
+	return c.Redirect("/auth/login", fiber.StatusFound)
+}
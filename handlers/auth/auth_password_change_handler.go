@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"zatrano/passwordpolicy"
+	"zatrano/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+const changePasswordPath = "/auth/change-password"
+
+// ShowChangePassword, şifresini değiştirmesi zorunlu olan kullanıcıya şifre değiştirme formunu gösterir.
+func (h *AuthHandler) ShowChangePassword(c *fiber.Ctx) error {
+	user, ok := utils.CurrentUser(c)
+	if !ok {
+		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	}
+
+	flashData, flashErr := utils.GetFlashMessages(c)
+	if flashErr != nil {
+		utils.Log.Warn("Şifre değiştirme formu: Flash mesajları alınamadı", zap.Error(flashErr))
+	}
+
+	reason := "Devam etmeden önce şifrenizi değiştirmeniz gerekiyor."
+	if !user.MustChangePassword && user.PasswordChangeRequired() {
+		reason = "Şifrenizin kullanım süresi doldu. Devam etmeden önce yeni bir şifre belirleyin."
+	}
+
+	return c.Render("auth/auth_change_password", fiber.Map{
+		"Title":         "Şifre Değiştir",
+		"Reason":        reason,
+		"PasswordRules": passwordpolicy.For(string(user.Type)).Requirements(),
+		"CsrfToken":     c.Locals("csrf"),
+		"Success":       flashData.Success,
+		"Error":         flashData.Error,
+	}, "layouts/auth_layout")
+}
+
+func (h *AuthHandler) ChangePassword(c *fiber.Ctx) error {
+	userID, ok := utils.CurrentUserID(c)
+	if !ok {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Geçersiz oturum bilgisi, lütfen tekrar giriş yapın.")
+		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	}
+
+	return h.changePassword(c, userID, changePasswordPath)
+}
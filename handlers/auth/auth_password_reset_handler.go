@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"errors"
+
+	"zatrano/models"
+	"zatrano/passwordpolicy"
+	"zatrano/services"
+	"zatrano/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+const forgotPasswordMessage = "Bu hesap kayıtlıysa, şifre sıfırlama bağlantısı e-posta adresine gönderildi."
+
+func (h *AuthHandler) ShowForgotPassword(c *fiber.Ctx) error {
+	flashData, err := utils.GetFlashMessages(c)
+	if err != nil {
+		utils.Log.Warn("Şifremi unuttum sayfası: Flash mesajları alınamadı", zap.Error(err))
+	}
+
+	return c.Render("auth/auth_forgot_password", fiber.Map{
+		"Title":     "Şifremi Unuttum",
+		"CsrfToken": c.Locals("csrf"),
+		"Success":   flashData.Success,
+		"Error":     flashData.Error,
+	}, "layouts/auth_layout")
+}
+
+func (h *AuthHandler) ForgotPassword(c *fiber.Ctx) error {
+	account := c.FormValue("account")
+	if account == "" {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Lütfen hesap adınızı girin.")
+		return c.Redirect("/auth/forgot", fiber.StatusSeeOther)
+	}
+
+	if err := h.passwordResetService.RequestReset(account, c.IP()); err != nil {
+		utils.Log.Error("Şifre sıfırlama isteği işlenemedi", zap.String("account", account), zap.Error(err))
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "İsteğiniz şu anda işlenemiyor. Lütfen daha sonra tekrar deneyin.")
+		return c.Redirect("/auth/forgot", fiber.StatusSeeOther)
+	}
+
+	_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, forgotPasswordMessage)
+	return c.Redirect("/auth/login", fiber.StatusSeeOther)
+}
+
+func (h *AuthHandler) ShowResetPassword(c *fiber.Ctx) error {
+	token := c.Params("token")
+	if err := h.passwordResetService.ValidateToken(token); err != nil {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Şifre sıfırlama bağlantısı geçersiz veya süresi dolmuş.")
+		return c.Redirect("/auth/forgot", fiber.StatusSeeOther)
+	}
+
+	flashData, err := utils.GetFlashMessages(c)
+	if err != nil {
+		utils.Log.Warn("Şifre sıfırlama sayfası: Flash mesajları alınamadı", zap.Error(err))
+	}
+
+	return c.Render("auth/auth_reset_password", fiber.Map{
+		"Title":     "Yeni Şifre Belirle",
+		"CsrfToken": c.Locals("csrf"),
+		"Token":     token,
+		"Success":   flashData.Success,
+		"Error":     flashData.Error,
+	}, "layouts/auth_layout")
+}
+
+func (h *AuthHandler) ResetPassword(c *fiber.Ctx) error {
+	token := c.Params("token")
+	resetPath := "/auth/reset/" + token
+
+	var request struct {
+		NewPassword     string `form:"new_password"`
+		ConfirmPassword string `form:"confirm_password"`
+	}
+	if err := c.BodyParser(&request); err != nil || request.NewPassword == "" || request.ConfirmPassword == "" {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Lütfen tüm şifre alanlarını doldurun.")
+		return c.Redirect(resetPath, fiber.StatusSeeOther)
+	}
+	if request.NewPassword != request.ConfirmPassword {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Yeni şifreler uyuşmuyor.")
+		return c.Redirect(resetPath, fiber.StatusSeeOther)
+	}
+
+	userID, err := h.passwordResetService.ResetPassword(token, request.NewPassword)
+	if err != nil {
+		switch err {
+		case services.ErrResetTokenInvalid:
+			_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Şifre sıfırlama bağlantısı geçersiz veya süresi dolmuş.")
+			return c.Redirect("/auth/forgot", fiber.StatusSeeOther)
+		default:
+			var policyErr *passwordpolicy.PolicyError
+			if errors.As(err, &policyErr) {
+				_ = utils.SetFlashMessage(c, utils.FlashErrorKey, policyErr.Error())
+				break
+			}
+			utils.Log.Error("Şifre sıfırlama servisinde beklenmeyen hata", zap.Error(err))
+			_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Şifre sıfırlanırken bir hata oluştu. Lütfen tekrar deneyin.")
+		}
+		return c.Redirect(resetPath, fiber.StatusSeeOther)
+	}
+
+	h.recordSecurityEvent(c, &userID, "", models.EventPasswordReset, "")
+
+	_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, "Şifreniz güncellendi. Yeni şifrenizle giriş yapabilirsiniz.")
+	return c.Redirect("/auth/login", fiber.StatusFound)
+}
@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"errors"
+
+	"zatrano/avatar"
+	"zatrano/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+const profilePath = "/auth/profile"
+
+// UpdateProfile, oturumdaki kullanıcının e-posta ve telefon bilgilerini günceller.
+func (h *AuthHandler) UpdateProfile(c *fiber.Ctx) error {
+	userID, ok := utils.CurrentUserID(c)
+	if !ok {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Geçersiz oturum bilgisi, lütfen tekrar giriş yapın.")
+		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	}
+
+	if err := h.userService.UpdateContactInfo(userID, c.FormValue("email"), c.FormValue("phone")); err != nil {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, err.Error())
+		return c.Redirect(profilePath, fiber.StatusSeeOther)
+	}
+
+	_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, "İletişim bilgileriniz güncellendi.")
+	return c.Redirect(profilePath, fiber.StatusSeeOther)
+}
+
+// UploadAvatar, oturumdaki kullanıcının profil resmini formdaki "avatar" dosyasıyla değiştirir.
+func (h *AuthHandler) UploadAvatar(c *fiber.Ctx) error {
+	userID, ok := utils.CurrentUserID(c)
+	if !ok {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Geçersiz oturum bilgisi, lütfen tekrar giriş yapın.")
+		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	}
+
+	if err := h.saveAvatarUpload(c, userID); err != nil {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, err.Error())
+		return c.Redirect(profilePath, fiber.StatusSeeOther)
+	}
+
+	_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, "Profil resminiz güncellendi.")
+	return c.Redirect(profilePath, fiber.StatusSeeOther)
+}
+
+func (h *AuthHandler) DeleteAvatar(c *fiber.Ctx) error {
+	userID, ok := utils.CurrentUserID(c)
+	if !ok {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Geçersiz oturum bilgisi, lütfen tekrar giriş yapın.")
+		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	}
+
+	if err := h.userService.RemoveAvatar(userID); err != nil {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, err.Error())
+		return c.Redirect(profilePath, fiber.StatusSeeOther)
+	}
+
+	_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, "Profil resminiz kaldırıldı.")
+	return c.Redirect(profilePath, fiber.StatusSeeOther)
+}
+
+// ShowAvatar, bir kullanıcının profil resmini döner. Kullanıcılar kendi resimlerini, kullanıcıları
+// görüntüleme yetkisi olanlar ise herkesin resmini görebilir.
+// saveAvatarUpload, formdaki "avatar" dosyasını kullanıcının profil resmi olarak kaydeder; dönen hata
+// kullanıcıya gösterilebilir.
+func (h *AuthHandler) saveAvatarUpload(c *fiber.Ctx, userID uint) error {
+	fileHeader, err := c.FormFile("avatar")
+	if err != nil {
+		return errors.New("lütfen bir resim dosyası seçin")
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		utils.Log.Warn("Profil resmi dosyası açılamadı", zap.Uint("user_id", userID), zap.Error(err))
+		return avatar.ErrInvalidImage
+	}
+	defer file.Close()
+
+	return h.userService.SetAvatar(userID, file)
+}
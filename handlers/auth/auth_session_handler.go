@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"zatrano/models"
+	"zatrano/services"
+	"zatrano/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+type sessionListItem struct {
+	models.UserSession
+	Current bool
+}
+
+func (h *AuthHandler) activeSessions(c *fiber.Ctx, userID uint) []sessionListItem {
+	sessions, err := h.sessionService.ListActive(userID)
+	if err != nil {
+		return nil
+	}
+
+	var currentID string
+	if sess, sessErr := utils.SessionStart(c); sessErr == nil {
+		currentID = sess.ID()
+	}
+
+	items := make([]sessionListItem, 0, len(sessions))
+	for _, userSession := range sessions {
+		items = append(items, sessionListItem{
+			UserSession: userSession,
+			Current:     h.sessionService.IsCurrent(userSession, currentID),
+		})
+	}
+	return items
+}
+
+func (h *AuthHandler) RevokeSession(c *fiber.Ctx) error {
+	user, ok := utils.CurrentUser(c)
+	if !ok {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Geçersiz oturum bilgisi, lütfen tekrar giriş yapın.")
+		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Geçersiz oturum.")
+		return c.Redirect("/auth/profile", fiber.StatusSeeOther)
+	}
+
+	if err := h.sessionService.Revoke(user.ID, uint(id)); err != nil {
+		errMsg := "Oturum sonlandırılamadı."
+		if err == services.ErrSessionNotFound {
+			errMsg = "Oturum bulunamadı veya zaten sonlandırılmış."
+		}
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, errMsg)
+		return c.Redirect("/auth/profile", fiber.StatusSeeOther)
+	}
+
+	_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, "Oturum sonlandırıldı.")
+	return c.Redirect("/auth/profile", fiber.StatusFound)
+}
+
+func (h *AuthHandler) RevokeOtherSessions(c *fiber.Ctx) error {
+	user, ok := utils.CurrentUser(c)
+	if !ok {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Geçersiz oturum bilgisi, lütfen tekrar giriş yapın.")
+		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	}
+
+	sess, err := utils.SessionStart(c)
+	if err != nil {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Oturum hatası, lütfen tekrar giriş yapın.")
+		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	}
+
+	count, err := h.sessionService.RevokeOthers(user.ID, sess.ID())
+	if err != nil {
+		utils.Log.Error("Diğer oturumlar sonlandırılamadı", zap.Uint("user_id", user.ID), zap.Error(err))
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Diğer oturumlar sonlandırılamadı.")
+		return c.Redirect("/auth/profile", fiber.StatusSeeOther)
+	}
+
+	if count == 0 {
+		_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, "Sonlandırılacak başka oturum bulunamadı.")
+	} else {
+		_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, "Diğer tüm oturumlar sonlandırıldı.")
+	}
+	return c.Redirect("/auth/profile", fiber.StatusFound)
+}
+
+func (h *AuthHandler) ForgetRememberedDevices(c *fiber.Ctx) error {
+	user, ok := utils.CurrentUser(c)
+	if !ok {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Geçersiz oturum bilgisi, lütfen tekrar giriş yapın.")
+		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	}
+
+	if _, err := h.rememberMeService.ForgetAll(user.ID); err != nil {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Hatırlanan cihazlar silinemedi.")
+		return c.Redirect("/auth/profile", fiber.StatusSeeOther)
+	}
+	utils.ClearRememberMeCookie(c)
+
+	_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, "Hatırlanan tüm cihazlar unutuldu. Bu cihazlarda yeniden giriş yapmanız gerekecek.")
+	return c.Redirect("/auth/profile", fiber.StatusFound)
+}
@@ -0,0 +1,303 @@
+package handlers
+
+import (
+	"errors"
+	"html/template"
+	"time"
+
+	"zatrano/models"
+	"zatrano/services"
+	"zatrano/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+const (
+	twoFactorChallengeTTL  = 5 * time.Minute
+	twoFactorMaxAttempts   = 5
+	twoFactorPendingSecret = "pending_2fa_secret"
+)
+
+func (h *AuthHandler) startTwoFactorChallenge(c *fiber.Ctx, user *models.User, remember bool) error {
+	sess, err := utils.SessionStart(c)
+	if err != nil {
+		utils.Log.Error("2FA: Oturum başlatılamadı", zap.Uint("user_id", user.ID), zap.Error(err))
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Oturum başlatılamadı. Lütfen tekrar deneyin.")
+		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	}
+
+	sess.Set("pending_2fa_user_id", user.ID)
+	sess.Set("pending_2fa_started_at", time.Now().Unix())
+	sess.Set("pending_2fa_attempts", 0)
+	sess.Set("pending_2fa_remember", remember)
+	if err := sess.Save(); err != nil {
+		utils.Log.Error("2FA: Oturum kaydedilemedi", zap.Uint("user_id", user.ID), zap.Error(err))
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Oturum bilgileri kaydedilemedi.")
+		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	}
+
+	return c.Redirect("/auth/2fa", fiber.StatusSeeOther)
+}
+
+func pendingTwoFactorUserID(c *fiber.Ctx) (uint, bool) {
+	sess, err := utils.SessionStart(c)
+	if err != nil {
+		return 0, false
+	}
+	userID, ok := sess.Get("pending_2fa_user_id").(uint)
+	if !ok {
+		return 0, false
+	}
+	startedAt, ok := sess.Get("pending_2fa_started_at").(int64)
+	if !ok || time.Since(time.Unix(startedAt, 0)) > twoFactorChallengeTTL {
+		return 0, false
+	}
+	return userID, true
+}
+
+func clearTwoFactorChallenge(c *fiber.Ctx) {
+	sess, err := utils.SessionStart(c)
+	if err != nil {
+		return
+	}
+	sess.Delete("pending_2fa_user_id")
+	sess.Delete("pending_2fa_started_at")
+	sess.Delete("pending_2fa_attempts")
+	sess.Delete("pending_2fa_remember")
+	_ = sess.Save()
+}
+
+func (h *AuthHandler) ShowTwoFactorChallenge(c *fiber.Ctx) error {
+	if _, ok := pendingTwoFactorUserID(c); !ok {
+		clearTwoFactorChallenge(c)
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Doğrulama süresi doldu. Lütfen tekrar giriş yapın.")
+		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	}
+
+	flashData, err := utils.GetFlashMessages(c)
+	if err != nil {
+		utils.Log.Warn("2FA sayfası: Flash mesajları alınamadı", zap.Error(err))
+	}
+
+	return c.Render("auth/auth_two_factor", fiber.Map{
+		"Title":     "İki Adımlı Doğrulama",
+		"CsrfToken": c.Locals("csrf"),
+		"Success":   flashData.Success,
+		"Error":     flashData.Error,
+	}, "layouts/auth_layout")
+}
+
+func (h *AuthHandler) VerifyTwoFactorChallenge(c *fiber.Ctx) error {
+	userID, ok := pendingTwoFactorUserID(c)
+	if !ok {
+		clearTwoFactorChallenge(c)
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Doğrulama süresi doldu. Lütfen tekrar giriş yapın.")
+		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	}
+
+	code := c.FormValue("code")
+	if code == "" {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Lütfen doğrulama kodunu girin.")
+		return c.Redirect("/auth/2fa", fiber.StatusSeeOther)
+	}
+
+	user, err := h.service.VerifyTwoFactor(userID, code, c.IP())
+	if err != nil {
+		var lockErr *services.LoginLockedError
+		if err == services.ErrAccountLocked || errors.As(err, &lockErr) {
+			utils.Log.Warn("2FA: Hatalı denemeler nedeniyle giriş engellendi", zap.Uint("user_id", userID))
+			h.recordSecurityEvent(c, &userID, "", models.EventLoginFailed, models.FailureInvalidTwoFactor)
+			clearTwoFactorChallenge(c)
+			_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Çok fazla hatalı doğrulama denemesi. Lütfen bir süre sonra tekrar giriş yapın.")
+			return c.Redirect("/auth/login", fiber.StatusSeeOther)
+		}
+		if err == services.ErrUserNotFound || err == services.ErrUserInactive {
+			clearTwoFactorChallenge(c)
+			_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Giriş tamamlanamadı. Lütfen tekrar deneyin.")
+			return c.Redirect("/auth/login", fiber.StatusSeeOther)
+		}
+
+		sess, sessErr := utils.SessionStart(c)
+		if sessErr != nil {
+			_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Oturum hatası, lütfen tekrar giriş yapın.")
+			return c.Redirect("/auth/login", fiber.StatusSeeOther)
+		}
+		attempts, _ := sess.Get("pending_2fa_attempts").(int)
+		attempts++
+		if attempts >= twoFactorMaxAttempts {
+			utils.Log.Warn("2FA: Çok fazla hatalı deneme, giriş iptal edildi", zap.Uint("user_id", userID))
+			h.recordSecurityEvent(c, &userID, "", models.EventLoginFailed, models.FailureInvalidTwoFactor)
+			clearTwoFactorChallenge(c)
+			_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Çok fazla hatalı doğrulama denemesi. Lütfen tekrar giriş yapın.")
+			return c.Redirect("/auth/login", fiber.StatusSeeOther)
+		}
+		sess.Set("pending_2fa_attempts", attempts)
+		_ = sess.Save()
+
+		h.recordSecurityEvent(c, &userID, "", models.EventLoginFailed, models.FailureInvalidTwoFactor)
+
+		errMsg := "Doğrulama kodu hatalı."
+		if err != services.ErrTwoFactorInvalidCode {
+			utils.Log.Error("2FA doğrulamasında beklenmeyen hata", zap.Uint("user_id", userID), zap.Error(err))
+			errMsg = "Doğrulama sırasında bir sorun oluştu. Lütfen tekrar deneyin."
+		}
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, errMsg)
+		return c.Redirect("/auth/2fa", fiber.StatusSeeOther)
+	}
+
+	remember := false
+	if sess, err := utils.SessionStart(c); err == nil {
+		remember, _ = sess.Get("pending_2fa_remember").(bool)
+	}
+
+	return h.completeLogin(c, user, remember)
+}
+
+func (h *AuthHandler) ShowTwoFactorSetup(c *fiber.Ctx) error {
+	user, ok := utils.CurrentUser(c)
+	if !ok {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Geçersiz oturum bilgisi, lütfen tekrar giriş yapın.")
+		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	}
+	if user.TwoFactorEnabled {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "İki adımlı doğrulama zaten etkin.")
+		return c.Redirect("/auth/profile", fiber.StatusSeeOther)
+	}
+
+	sess, err := utils.SessionStart(c)
+	if err != nil {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Oturum hatası, lütfen tekrar giriş yapın.")
+		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	}
+
+	secret, _ := sess.Get(twoFactorPendingSecret).(string)
+	if secret == "" {
+		secret, err = h.twoFactorService.NewSecret()
+		if err != nil {
+			_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "İki adımlı doğrulama kurulumu başlatılamadı.")
+			return c.Redirect("/auth/profile", fiber.StatusSeeOther)
+		}
+		sess.Set(twoFactorPendingSecret, secret)
+		if err := sess.Save(); err != nil {
+			utils.Log.Error("2FA kurulum anahtarı oturuma kaydedilemedi", zap.Uint("user_id", user.ID), zap.Error(err))
+		}
+	}
+
+	flashData, flashErr := utils.GetFlashMessages(c)
+	if flashErr != nil {
+		utils.Log.Warn("2FA kurulum sayfası: Flash mesajları alınamadı", zap.Error(flashErr))
+	}
+
+	provisioningURI := h.twoFactorService.ProvisioningURI(user.Account, secret)
+	qrCode, err := utils.TOTPQRCodeDataURI(provisioningURI, 180)
+	if err != nil {
+		utils.Log.Warn("2FA kurulum QR kodu üretilemedi", zap.Uint("user_id", user.ID), zap.Error(err))
+	}
+
+	return c.Render("auth/auth_two_factor_setup", fiber.Map{
+		"Title":           "İki Adımlı Doğrulama Kurulumu",
+		"CsrfToken":       c.Locals("csrf"),
+		"Secret":          secret,
+		"ProvisioningURI": provisioningURI,
+		"QRCode":          template.URL(qrCode),
+		"Success":         flashData.Success,
+		"Error":           flashData.Error,
+	}, "layouts/auth_layout")
+}
+
+func (h *AuthHandler) ConfirmTwoFactorSetup(c *fiber.Ctx) error {
+	user, ok := utils.CurrentUser(c)
+	if !ok {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Geçersiz oturum bilgisi, lütfen tekrar giriş yapın.")
+		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	}
+
+	sess, err := utils.SessionStart(c)
+	if err != nil {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Oturum hatası, lütfen tekrar giriş yapın.")
+		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	}
+	secret, _ := sess.Get(twoFactorPendingSecret).(string)
+	if secret == "" {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Kurulum oturumu bulunamadı, lütfen tekrar deneyin.")
+		return c.Redirect("/auth/2fa/setup", fiber.StatusSeeOther)
+	}
+
+	codes, err := h.twoFactorService.ConfirmEnrollment(user.ID, secret, c.FormValue("code"))
+	if err != nil {
+		errMsg := "İki adımlı doğrulama etkinleştirilemedi."
+		switch err {
+		case services.ErrTwoFactorInvalidCode:
+			errMsg = "Doğrulama kodu hatalı. Uygulamadaki güncel kodu girin."
+		case services.ErrTwoFactorAlreadyEnabled:
+			_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "İki adımlı doğrulama zaten etkin.")
+			return c.Redirect("/auth/profile", fiber.StatusSeeOther)
+		}
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, errMsg)
+		return c.Redirect("/auth/2fa/setup", fiber.StatusSeeOther)
+	}
+
+	sess.Delete(twoFactorPendingSecret)
+	sess.Delete("two_factor_setup_required")
+	if err := sess.Save(); err != nil {
+		utils.Log.Error("2FA kurulumu sonrası oturum kaydedilemedi", zap.Uint("user_id", user.ID), zap.Error(err))
+	}
+
+	return c.Render("auth/auth_two_factor_recovery_codes", fiber.Map{
+		"Title":         "Kurtarma Kodları",
+		"CsrfToken":     c.Locals("csrf"),
+		"RecoveryCodes": codes,
+		"Success":       "İki adımlı doğrulama etkinleştirildi.",
+	}, "layouts/auth_layout")
+}
+
+func (h *AuthHandler) DisableTwoFactor(c *fiber.Ctx) error {
+	user, ok := utils.CurrentUser(c)
+	if !ok {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Geçersiz oturum bilgisi, lütfen tekrar giriş yapın.")
+		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	}
+
+	if err := h.twoFactorService.Disable(user.ID, c.FormValue("code")); err != nil {
+		var errMsg string
+		switch err {
+		case services.ErrTwoFactorInvalidCode:
+			errMsg = "Doğrulama kodu hatalı."
+		case services.ErrTwoFactorRequiredByPolicy, services.ErrTwoFactorNotEnabled:
+			errMsg = "İki adımlı doğrulama kapatılamadı: " + err.Error()
+		default:
+			errMsg = "İki adımlı doğrulama kapatılırken bir hata oluştu."
+		}
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, errMsg)
+		return c.Redirect("/auth/profile", fiber.StatusSeeOther)
+	}
+
+	_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, "İki adımlı doğrulama kapatıldı.")
+	return c.Redirect("/auth/profile", fiber.StatusFound)
+}
+
+func (h *AuthHandler) RegenerateRecoveryCodes(c *fiber.Ctx) error {
+	user, ok := utils.CurrentUser(c)
+	if !ok {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Geçersiz oturum bilgisi, lütfen tekrar giriş yapın.")
+		return c.Redirect("/auth/login", fiber.StatusSeeOther)
+	}
+
+	codes, err := h.twoFactorService.RegenerateRecoveryCodes(user.ID, c.FormValue("code"))
+	if err != nil {
+		errMsg := "Kurtarma kodları yenilenemedi."
+		if err == services.ErrTwoFactorInvalidCode {
+			errMsg = "Doğrulama kodu hatalı."
+		}
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, errMsg)
+		return c.Redirect("/auth/profile", fiber.StatusSeeOther)
+	}
+
+	return c.Render("auth/auth_two_factor_recovery_codes", fiber.Map{
+		"Title":         "Kurtarma Kodları",
+		"CsrfToken":     c.Locals("csrf"),
+		"RecoveryCodes": codes,
+		"Success":       "Kurtarma kodları yenilendi.",
+	}, "layouts/auth_layout")
+}
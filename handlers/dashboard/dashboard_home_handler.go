@@ -31,10 +31,11 @@ func (h *HomeHandler) HomePage(c *fiber.Ctx) error {
 	}
 
 	mapData := fiber.Map{
-		"Title":     "Dashboard",
-		"Success":   flashData.Success,
-		"Error":     flashData.Error,
-		"UserCount": userCount,
+		"Title":      "Dashboard",
+		"Success":    flashData.Success,
+		"Error":      flashData.Error,
+		"UserCount":  userCount,
+		"CacheStats": h.userService.GetCacheStats(),
 	}
 
 	return c.Render("dashboard/home/dashboard_home", mapData, "layouts/dashboard_layout")
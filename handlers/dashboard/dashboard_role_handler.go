@@ -0,0 +1,201 @@
+package handlers
+
+import (
+	"strconv"
+
+	"zatrano/models"
+	"zatrano/services"
+	"zatrano/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+type RoleHandler struct {
+	roleService services.IRoleService
+}
+
+func NewRoleHandler() *RoleHandler {
+	return &RoleHandler{
+		roleService: services.NewRoleService(),
+	}
+}
+
+// roleListItem, rol listesinde her role atanmış kullanıcı sayısını da göstermek için kullanılır.
+type roleListItem struct {
+	models.Role
+	UserCount int64
+}
+
+func (h *RoleHandler) ListRoles(c *fiber.Ctx) error {
+	flashData, flashErr := utils.GetFlashMessages(c)
+	if flashErr != nil {
+		utils.Log.Warn("Rol listesi: Flash mesajları alınamadı", zap.Error(flashErr))
+	}
+
+	roles, err := h.roleService.GetRoles()
+	if err != nil {
+		flashData.Error = "Roller alınırken bir hata oluştu."
+	}
+
+	items := make([]roleListItem, 0, len(roles))
+	for _, role := range roles {
+		items = append(items, roleListItem{Role: role, UserCount: h.roleService.CountRoleUsers(role.ID)})
+	}
+
+	return c.Render("dashboard/roles/dashboard_roles_list", fiber.Map{
+		"Title":     "Roller",
+		"CsrfToken": c.Locals("csrf"),
+		"Roles":     items,
+		"Success":   flashData.Success,
+		"Error":     flashData.Error,
+	}, "layouts/dashboard_layout")
+}
+
+func (h *RoleHandler) ShowCreateRole(c *fiber.Ctx) error {
+	flashData, flashErr := utils.GetFlashMessages(c)
+	if flashErr != nil {
+		utils.Log.Warn("Rol oluşturma formu: Flash mesajları alınamadı", zap.Error(flashErr))
+	}
+
+	return c.Render("dashboard/roles/dashboard_roles_create", fiber.Map{
+		"Title":       "Yeni Rol Ekle",
+		"CsrfToken":   c.Locals("csrf"),
+		"Permissions": h.permissions(),
+		"Role":        &models.Role{},
+		"Success":     flashData.Success,
+		"Error":       flashData.Error,
+	}, "layouts/dashboard_layout")
+}
+
+func (h *RoleHandler) CreateRole(c *fiber.Ctx) error {
+	permissionKeys := formValues(c, "permissions")
+	role := &models.Role{
+		Name:        c.FormValue("name"),
+		Slug:        c.FormValue("slug"),
+		Description: c.FormValue("description"),
+	}
+
+	actorID, _ := utils.CurrentUserID(c)
+	if err := h.roleService.CreateRole(actorID, role, permissionKeys); err != nil {
+		formRole := &models.Role{Name: role.Name, Slug: role.Slug, Description: role.Description}
+		for _, key := range permissionKeys {
+			formRole.Permissions = append(formRole.Permissions, models.Permission{Key: key})
+		}
+		return c.Status(fiber.StatusBadRequest).Render("dashboard/roles/dashboard_roles_create", fiber.Map{
+			"Title":       "Yeni Rol Ekle",
+			"CsrfToken":   c.Locals("csrf"),
+			"Permissions": h.permissions(),
+			"Role":        formRole,
+			"Error":       "Rol oluşturulamadı: " + err.Error(),
+		}, "layouts/dashboard_layout")
+	}
+
+	_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, "Rol başarıyla oluşturuldu.")
+	return c.Redirect("/dashboard/roles", fiber.StatusFound)
+}
+
+func (h *RoleHandler) ShowUpdateRole(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Geçersiz rol ID'si.")
+		return c.Redirect("/dashboard/roles", fiber.StatusSeeOther)
+	}
+
+	role, err := h.roleService.GetRoleByID(uint(id))
+	if err != nil {
+		errMsg := "Rol bilgileri alınırken hata oluştu."
+		if err == services.ErrRoleNotFound {
+			errMsg = "Düzenlenecek rol bulunamadı."
+		}
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, errMsg)
+		return c.Redirect("/dashboard/roles", fiber.StatusSeeOther)
+	}
+
+	flashData, flashErr := utils.GetFlashMessages(c)
+	if flashErr != nil {
+		utils.Log.Warn("Rol düzenleme formu: Flash mesajları alınamadı", zap.Error(flashErr))
+	}
+
+	return c.Render("dashboard/roles/dashboard_roles_update", fiber.Map{
+		"Title":       "Rol Düzenle",
+		"CsrfToken":   c.Locals("csrf"),
+		"Permissions": h.permissions(),
+		"Role":        role,
+		"UserCount":   h.roleService.CountRoleUsers(role.ID),
+		"Success":     flashData.Success,
+		"Error":       flashData.Error,
+	}, "layouts/dashboard_layout")
+}
+
+func (h *RoleHandler) UpdateRole(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Geçersiz rol ID'si.")
+		return c.Redirect("/dashboard/roles", fiber.StatusSeeOther)
+	}
+	roleID := uint(id)
+
+	actorID, _ := utils.CurrentUserID(c)
+	err = h.roleService.UpdateRole(actorID, roleID, c.FormValue("name"), c.FormValue("description"), formValues(c, "permissions"))
+	if err != nil {
+		if err == services.ErrRoleNotFound {
+			_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Güncellenecek rol bulunamadı.")
+			return c.Redirect("/dashboard/roles", fiber.StatusSeeOther)
+		}
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Rol güncellenemedi: "+err.Error())
+		return c.Redirect("/dashboard/roles/update/"+strconv.Itoa(id), fiber.StatusSeeOther)
+	}
+
+	_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, "Rol başarıyla güncellendi.")
+	return c.Redirect("/dashboard/roles", fiber.StatusFound)
+}
+
+func (h *RoleHandler) DeleteRole(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Geçersiz rol ID'si.")
+		return c.Redirect("/dashboard/roles", fiber.StatusSeeOther)
+	}
+
+	if err := h.roleService.DeleteRole(uint(id)); err != nil {
+		errMsg := "Rol silinemedi: " + err.Error()
+		if err == services.ErrRoleNotFound {
+			errMsg = "Silinecek rol bulunamadı."
+		}
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, errMsg)
+		return c.Redirect("/dashboard/roles", fiber.StatusSeeOther)
+	}
+
+	_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, "Rol başarıyla silindi.")
+	return c.Redirect("/dashboard/roles", fiber.StatusFound)
+}
+
+func (h *RoleHandler) permissions() []models.Permission {
+	permissions, err := h.roleService.GetPermissions()
+	if err != nil {
+		return nil
+	}
+	return permissions
+}
+
+// formValues, aynı adla gönderilen birden fazla form alanının (ör. onay kutuları) tüm değerlerini döner.
+func formValues(c *fiber.Ctx, key string) []string {
+	var values []string
+	for _, v := range c.Request().PostArgs().PeekMulti(key) {
+		values = append(values, string(v))
+	}
+	return values
+}
+
+// formIDs, formValues ile okunan değerlerden geçerli pozitif ID'leri ayıklar.
+func formIDs(c *fiber.Ctx, key string) []uint {
+	var ids []uint
+	for _, v := range formValues(c, key) {
+		id, err := strconv.ParseUint(v, 10, 64)
+		if err == nil && id > 0 {
+			ids = append(ids, uint(id))
+		}
+	}
+	return ids
+}
@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"html/template"
+	"net/url"
+	"strconv"
+
+	"zatrano/models"
+	"zatrano/services"
+	"zatrano/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+type SecurityEventHandler struct {
+	securityEventService services.ISecurityEventService
+}
+
+func NewSecurityEventHandler() *SecurityEventHandler {
+	return &SecurityEventHandler{
+		securityEventService: services.NewSecurityEventService(),
+	}
+}
+
+func (h *SecurityEventHandler) ListEvents(c *fiber.Ctx) error {
+	flashData, flashErr := utils.GetFlashMessages(c)
+	if flashErr != nil {
+		utils.Log.Warn("Güvenlik olayları: Flash mesajları alınamadı", zap.Error(flashErr))
+	}
+
+	var params utils.ListParams
+	if err := c.QueryParser(&params); err != nil {
+		utils.Log.Warn("Güvenlik olayları: Query parametreleri parse edilemedi, varsayılanlar kullanılıyor.", zap.Error(err))
+		params = utils.ListParams{}
+	}
+	var filter utils.SecurityEventFilter
+	if err := c.QueryParser(&filter); err != nil {
+		utils.Log.Warn("Güvenlik olayları: Filtre parametreleri parse edilemedi.", zap.Error(err))
+		filter = utils.SecurityEventFilter{}
+	}
+
+	if params.Page <= 0 {
+		params.Page = utils.DefaultPage
+	}
+	if params.PerPage <= 0 || params.PerPage > utils.MaxPerPage {
+		params.PerPage = utils.DefaultPerPage
+	}
+	if params.SortBy == "" {
+		params.SortBy = utils.DefaultSortBy
+	}
+	if params.OrderBy == "" {
+		params.OrderBy = utils.DefaultOrderBy
+	}
+
+	paginatedResult, dbErr := h.securityEventService.GetEventsPaginated(params, filter)
+
+	// Sayfalama ve sıralama bağlantılarının filtreleri koruması için ortak sorgu parçası.
+	filterQuery := url.Values{}
+	filterQuery.Set("perPage", strconv.Itoa(params.PerPage))
+	if params.Name != "" {
+		filterQuery.Set("name", params.Name)
+	}
+	if filter.UserID > 0 {
+		filterQuery.Set("userId", strconv.FormatUint(uint64(filter.UserID), 10))
+	}
+	if filter.Type != "" {
+		filterQuery.Set("type", filter.Type)
+	}
+	if filter.Outcome != "" {
+		filterQuery.Set("outcome", filter.Outcome)
+	}
+	if filter.IP != "" {
+		filterQuery.Set("ip", filter.IP)
+	}
+
+	renderData := fiber.Map{
+		"Title":       "Giriş Geçmişi",
+		"CsrfToken":   c.Locals("csrf"),
+		"Result":      paginatedResult,
+		"Params":      params,
+		"Filter":      filter,
+		"FilterQuery": template.URL(filterQuery.Encode()),
+		"EventTypes":  models.SecurityEventTypes(),
+		"Success":     flashData.Success,
+		"Error":       flashData.Error,
+	}
+
+	if dbErr != nil {
+		utils.Log.Error("Güvenlik olayları DB Hatası", zap.Error(dbErr))
+		renderData["Error"] = "Güvenlik olayları getirilirken bir hata oluştu."
+		renderData["Result"] = &utils.PaginatedResult{
+			Data: []models.SecurityEvent{},
+			Meta: utils.PaginationMeta{CurrentPage: params.Page, PerPage: params.PerPage},
+		}
+	}
+
+	return c.Render("dashboard/security/dashboard_security_events", renderData, "layouts/dashboard_layout")
+}
@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"strconv"
+
+	"zatrano/models"
+	"zatrano/passwordpolicy"
+	"zatrano/services"
+	"zatrano/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+type SecurityPolicyHandler struct {
+	policyService services.IUserTypePolicyService
+}
+
+func NewSecurityPolicyHandler() *SecurityPolicyHandler {
+	return &SecurityPolicyHandler{
+		policyService: services.NewUserTypePolicyService(),
+	}
+}
+
+func (h *SecurityPolicyHandler) ShowPolicies(c *fiber.Ctx) error {
+	flashData, flashErr := utils.GetFlashMessages(c)
+	if flashErr != nil {
+		utils.Log.Warn("Güvenlik politikaları: Flash mesajları alınamadı", zap.Error(flashErr))
+	}
+
+	policies, err := h.policyService.GetPolicies()
+	if err != nil {
+		utils.Log.Error("Güvenlik politikaları alınamadı", zap.Error(err))
+		flashData.Error = "Güvenlik politikaları alınırken bir hata oluştu."
+	}
+
+	return c.Render("dashboard/security/dashboard_security_policies", fiber.Map{
+		"Title":     "Güvenlik Politikaları",
+		"CsrfToken": c.Locals("csrf"),
+		"Policies":  policies,
+		"Success":   flashData.Success,
+		"Error":     flashData.Error,
+	}, "layouts/dashboard_layout")
+}
+
+func (h *SecurityPolicyHandler) UpdatePolicies(c *fiber.Ctx) error {
+	for _, userType := range models.AllUserTypes() {
+		required := c.FormValue("require_two_factor_"+string(userType)) == "true"
+		if err := h.policyService.SetTwoFactorRequired(userType, required); err != nil {
+			utils.Log.Error("Güvenlik politikası güncellenemedi", zap.String("type", string(userType)), zap.Error(err))
+			_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Güvenlik politikaları güncellenemedi: "+err.Error())
+			return c.Redirect("/dashboard/security-policies", fiber.StatusSeeOther)
+		}
+
+		passwordPolicy, err := parsePasswordPolicy(c, string(userType))
+		if err == nil {
+			err = h.policyService.UpdatePasswordPolicy(userType, passwordPolicy)
+		}
+		if err != nil {
+			utils.Log.Warn("Şifre politikası güncellenemedi", zap.String("type", string(userType)), zap.Error(err))
+			_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Şifre politikası güncellenemedi: "+err.Error())
+			return c.Redirect("/dashboard/security-policies", fiber.StatusSeeOther)
+		}
+	}
+
+	_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, "Güvenlik politikaları güncellendi.")
+	return c.Redirect("/dashboard/security-policies", fiber.StatusFound)
+}
+
+func parsePasswordPolicy(c *fiber.Ctx, userType string) (passwordpolicy.Policy, error) {
+	minLength, err := strconv.Atoi(c.FormValue("password_min_length_" + userType))
+	if err != nil {
+		return passwordpolicy.Policy{}, services.ErrPolicyInvalidMinLen
+	}
+	historyCount, err := strconv.Atoi(c.FormValue("password_history_count_" + userType))
+	if err != nil {
+		return passwordpolicy.Policy{}, services.ErrPolicyInvalidHistory
+	}
+	maxAgeDays, err := strconv.Atoi(c.FormValue("password_max_age_days_" + userType))
+	if err != nil {
+		return passwordpolicy.Policy{}, services.ErrPolicyInvalidMaxAge
+	}
+
+	checked := func(name string) bool {
+		return c.FormValue(name+"_"+userType) == "true"
+	}
+
+	return passwordpolicy.Policy{
+		MinLength:           minLength,
+		RequireUpper:        checked("password_require_upper"),
+		RequireLower:        checked("password_require_lower"),
+		RequireDigit:        checked("password_require_digit"),
+		RequireSymbol:       checked("password_require_symbol"),
+		DisallowAccountName: checked("password_disallow_account"),
+		DisallowCommon:      checked("password_disallow_common"),
+		HistoryCount:        historyCount,
+		MaxAgeDays:          maxAgeDays,
+	}, nil
+}
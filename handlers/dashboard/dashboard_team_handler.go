@@ -0,0 +1,161 @@
+package handlers
+
+import (
+	"strconv"
+
+	"zatrano/models"
+	"zatrano/services"
+	"zatrano/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+type TeamHandler struct {
+	teamService services.ITeamService
+}
+
+func NewTeamHandler() *TeamHandler {
+	return &TeamHandler{
+		teamService: services.NewTeamService(),
+	}
+}
+
+func (h *TeamHandler) ListTeams(c *fiber.Ctx) error {
+	flashData, flashErr := utils.GetFlashMessages(c)
+	if flashErr != nil {
+		utils.Log.Warn("Takım listesi: Flash mesajları alınamadı", zap.Error(flashErr))
+	}
+
+	teams, err := h.teamService.GetTeamsWithMemberCount()
+	if err != nil {
+		flashData.Error = "Takımlar alınırken bir hata oluştu."
+	}
+
+	return c.Render("dashboard/teams/dashboard_teams_list", fiber.Map{
+		"Title":     "Takımlar",
+		"CsrfToken": c.Locals("csrf"),
+		"Teams":     teams,
+		"Success":   flashData.Success,
+		"Error":     flashData.Error,
+	}, "layouts/dashboard_layout")
+}
+
+func (h *TeamHandler) ShowCreateTeam(c *fiber.Ctx) error {
+	flashData, flashErr := utils.GetFlashMessages(c)
+	if flashErr != nil {
+		utils.Log.Warn("Takım oluşturma formu: Flash mesajları alınamadı", zap.Error(flashErr))
+	}
+
+	return c.Render("dashboard/teams/dashboard_teams_create", fiber.Map{
+		"Title":     "Yeni Takım Ekle",
+		"CsrfToken": c.Locals("csrf"),
+		"Team":      &models.Team{Status: true},
+		"Success":   flashData.Success,
+		"Error":     flashData.Error,
+	}, "layouts/dashboard_layout")
+}
+
+func (h *TeamHandler) CreateTeam(c *fiber.Ctx) error {
+	team := &models.Team{
+		Name:        c.FormValue("name"),
+		Description: c.FormValue("description"),
+		Status:      c.FormValue("status") == "true",
+	}
+
+	if err := h.teamService.CreateTeam(team); err != nil {
+		return c.Status(fiber.StatusBadRequest).Render("dashboard/teams/dashboard_teams_create", fiber.Map{
+			"Title":     "Yeni Takım Ekle",
+			"CsrfToken": c.Locals("csrf"),
+			"Team":      team,
+			"Error":     "Takım oluşturulamadı: " + err.Error(),
+		}, "layouts/dashboard_layout")
+	}
+
+	_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, "Takım başarıyla oluşturuldu.")
+	return c.Redirect("/dashboard/teams", fiber.StatusFound)
+}
+
+func (h *TeamHandler) ShowUpdateTeam(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Geçersiz takım ID'si.")
+		return c.Redirect("/dashboard/teams", fiber.StatusSeeOther)
+	}
+	teamID := uint(id)
+
+	team, err := h.teamService.GetTeamByID(teamID)
+	if err != nil {
+		errMsg := "Takım bilgileri alınırken hata oluştu."
+		if err == services.ErrTeamNotFound {
+			errMsg = "Düzenlenecek takım bulunamadı."
+		}
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, errMsg)
+		return c.Redirect("/dashboard/teams", fiber.StatusSeeOther)
+	}
+
+	members, err := h.teamService.GetMembers(teamID)
+	if err != nil {
+		members = nil
+	}
+
+	flashData, flashErr := utils.GetFlashMessages(c)
+	if flashErr != nil {
+		utils.Log.Warn("Takım düzenleme formu: Flash mesajları alınamadı", zap.Error(flashErr))
+	}
+
+	return c.Render("dashboard/teams/dashboard_teams_update", fiber.Map{
+		"Title":     "Takım Düzenle",
+		"CsrfToken": c.Locals("csrf"),
+		"Team":      team,
+		"Members":   members,
+		"Success":   flashData.Success,
+		"Error":     flashData.Error,
+	}, "layouts/dashboard_layout")
+}
+
+func (h *TeamHandler) UpdateTeam(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Geçersiz takım ID'si.")
+		return c.Redirect("/dashboard/teams", fiber.StatusSeeOther)
+	}
+
+	team := &models.Team{
+		Name:        c.FormValue("name"),
+		Description: c.FormValue("description"),
+		Status:      c.FormValue("status") == "true",
+	}
+
+	if err := h.teamService.UpdateTeam(uint(id), team); err != nil {
+		if err == services.ErrTeamNotFound {
+			_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Güncellenecek takım bulunamadı.")
+			return c.Redirect("/dashboard/teams", fiber.StatusSeeOther)
+		}
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Takım güncellenemedi: "+err.Error())
+		return c.Redirect("/dashboard/teams/update/"+strconv.Itoa(id), fiber.StatusSeeOther)
+	}
+
+	_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, "Takım başarıyla güncellendi.")
+	return c.Redirect("/dashboard/teams", fiber.StatusFound)
+}
+
+func (h *TeamHandler) DeleteTeam(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Geçersiz takım ID'si.")
+		return c.Redirect("/dashboard/teams", fiber.StatusSeeOther)
+	}
+
+	if err := h.teamService.DeleteTeam(uint(id)); err != nil {
+		errMsg := "Takım silinemedi: " + err.Error()
+		if err == services.ErrTeamNotFound {
+			errMsg = "Silinecek takım bulunamadı."
+		}
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, errMsg)
+		return c.Redirect("/dashboard/teams", fiber.StatusSeeOther)
+	}
+
+	_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, "Takım silindi; üyeleri takımsız olarak kaldı.")
+	return c.Redirect("/dashboard/teams", fiber.StatusFound)
+}
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"fmt"
+	"strings"
+
+	"zatrano/models"
+	"zatrano/services"
+	"zatrano/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const usersListPath = "/dashboard/users"
+
+// BulkSetStatus, listede seçilen kullanıcıları formdaki "status" değerine göre aktif veya pasif yapar.
+func (h *UserHandler) BulkSetStatus(c *fiber.Ctx) error {
+	actorID, _ := utils.CurrentUserID(c)
+	status := c.FormValue("status") == "true"
+
+	result, err := h.userService.BulkSetStatus(actorID, h.actorScope(c), formIDs(c, "user_ids"), status)
+	verb := "aktifleştirildi"
+	if !status {
+		verb = "pasifleştirildi"
+	}
+	return h.finishBulk(c, result, err, verb)
+}
+
+func (h *UserHandler) BulkSetType(c *fiber.Ctx) error {
+	actorID, _ := utils.CurrentUserID(c)
+	userType := models.UserType(c.FormValue("type"))
+
+	result, err := h.userService.BulkSetType(actorID, h.actorScope(c), formIDs(c, "user_ids"), userType)
+	return h.finishBulk(c, result, err, "için tip değiştirildi")
+}
+
+func (h *UserHandler) BulkDelete(c *fiber.Ctx) error {
+	actorID, _ := utils.CurrentUserID(c)
+
+	result, err := h.userService.BulkDelete(actorID, h.actorScope(c), formIDs(c, "user_ids"))
+	return h.finishBulk(c, result, err, "silindi")
+}
+
+// finishBulk, toplu işlemin sonucunu başarı ve atlanan kayıtlar için ayrı flash mesajlarıyla raporlar.
+func (h *UserHandler) finishBulk(c *fiber.Ctx, result *services.BulkResult, err error, verb string) error {
+	if err != nil {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Toplu işlem yapılamadı: "+err.Error())
+		return c.Redirect(usersListPath, fiber.StatusSeeOther)
+	}
+
+	if len(result.Succeeded) > 0 {
+		_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, fmt.Sprintf("%d kullanıcı %s.", len(result.Succeeded), verb))
+	}
+	if len(result.Skipped) > 0 {
+		reasons := make([]string, 0, len(result.Skipped))
+		for _, skipped := range result.Skipped {
+			label := skipped.Account
+			if label == "" {
+				label = fmt.Sprintf("#%d", skipped.ID)
+			}
+			reasons = append(reasons, fmt.Sprintf("%s (%s)", label, skipped.Reason.Error()))
+		}
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey,
+			fmt.Sprintf("%d kullanıcı atlandı: %s", len(result.Skipped), strings.Join(reasons, "; ")))
+	}
+
+	return c.Redirect(usersListPath, fiber.StatusFound)
+}
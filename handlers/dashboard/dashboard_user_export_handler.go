@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+	"time"
+
+	"zatrano/spreadsheet"
+	"zatrano/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// ExportUsers, kullanıcı listesindeki filtre ve sıralamaya uyan tüm kullanıcıları "format" parametresine
+// göre CSV, XLSX veya JSON olarak indirir. Yanıt, kayıtlar veritabanından okundukça akış halinde yazılır.
+func (h *UserHandler) ExportUsers(c *fiber.Ctx) error {
+	format := strings.ToLower(c.Query("format", spreadsheet.FormatCSV))
+	if !spreadsheet.IsSupportedFormat(format) {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, spreadsheet.ErrUnsupportedExportFormat.Error())
+		return c.Redirect(usersListPath, fiber.StatusFound)
+	}
+
+	var params utils.ListParams
+	if err := c.QueryParser(&params); err != nil {
+		utils.Log.Warn("Kullanıcı dışa aktarma: Query parametreleri parse edilemedi, varsayılanlar kullanılıyor.", zap.Error(err))
+		params = utils.ListParams{}
+	}
+	if params.SortBy == "" {
+		params.SortBy = utils.DefaultSortBy
+		if params.Name != "" {
+			params.SortBy = utils.SortByRelevance
+		}
+	}
+	if params.OrderBy == "" {
+		params.OrderBy = utils.DefaultOrderBy
+	}
+	var filter utils.UserFilter
+	if err := c.QueryParser(&filter); err != nil {
+		utils.Log.Warn("Kullanıcı dışa aktarma: Filtre parametreleri parse edilemedi.", zap.Error(err))
+		filter = utils.UserFilter{}
+	}
+
+	scope := h.actorScope(c)
+	actorID, _ := utils.CurrentUserID(c)
+	fileName := fmt.Sprintf("kullanicilar_%s.%s", time.Now().Format("20060102_150405"), format)
+
+	c.Set(fiber.HeaderContentType, spreadsheet.ContentType(format))
+	c.Set(fiber.HeaderContentDisposition, `attachment; filename="`+fileName+`"`)
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		// Başlıklar gönderildikten sonra durum kodu değiştirilemez; hata yalnızca loglanır ve yanıt yarım kalır.
+		if err := h.userService.ExportUsers(w, format, params, filter, scope); err != nil {
+			utils.Log.Error("Kullanıcı dışa aktarma yarıda kaldı", zap.Uint("actor_id", actorID), zap.String("format", format), zap.Error(err))
+		}
+		if err := w.Flush(); err != nil {
+			utils.Log.Warn("Kullanıcı dışa aktarma: yanıt yazılamadı", zap.Error(err))
+		}
+	})
+	return nil
+}
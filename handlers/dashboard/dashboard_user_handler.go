@@ -1,7 +1,15 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
+	"html/template"
+	"mime/multipart"
+	"strconv"
+
+	"zatrano/avatar"
 	"zatrano/models"
+	"zatrano/passwordpolicy"
 	"zatrano/services"
 	"zatrano/utils"
 
@@ -10,12 +18,24 @@ import (
 )
 
 type UserHandler struct {
-	userService services.IUserService
+	userService          services.IUserService
+	twoFactorService     services.ITwoFactorService
+	sessionService       services.ISessionService
+	securityEventService services.ISecurityEventService
+	roleService          services.IRoleService
+	teamService          services.ITeamService
+	invitationService    services.IUserInvitationService
 }
 
 func NewUserHandler() *UserHandler {
 	return &UserHandler{
-		userService: services.NewUserService(),
+		userService:          services.NewUserService(),
+		twoFactorService:     services.NewTwoFactorService(),
+		sessionService:       services.NewSessionService(),
+		securityEventService: services.NewSecurityEventService(),
+		roleService:          services.NewRoleService(),
+		teamService:          services.NewTeamService(),
+		invitationService:    services.NewUserInvitationService(),
 	}
 }
 
@@ -47,36 +67,82 @@ func (h *UserHandler) ListUsers(c *fiber.Ctx) error {
 	}
 	if params.SortBy == "" {
 		params.SortBy = utils.DefaultSortBy
+		if params.Name != "" {
+			params.SortBy = utils.SortByRelevance
+		}
 	}
 	if params.OrderBy == "" {
 		params.OrderBy = utils.DefaultOrderBy
 	}
+	var filter utils.UserFilter
+	if err := c.QueryParser(&filter); err != nil {
+		utils.Log.Warn("Kullanıcı listesi: Filtre parametreleri parse edilemedi.", zap.Error(err))
+		filter = utils.UserFilter{}
+	}
 
-	paginatedResult, dbErr := h.userService.GetAllUsersPaginated(params)
+	scope := h.actorScope(c)
+
+	// Sayfalama ve sıralama bağlantılarının filtreleri koruması için ortak sorgu parçası.
+	filterQuery := filter.QueryString()
+
+	cursorMode := h.userService.UsesCursorPaging(params)
+	var result interface{}
+	var dbErr error
+	if cursorMode {
+		params.Paging = utils.PagingCursor
+		filterQuery += "&paging=" + utils.PagingCursor
+		var cursorResult *utils.CursorPaginatedResult
+		cursorResult, dbErr = h.userService.GetUsersByCursor(params, filter, scope)
+		if dbErr != nil {
+			cursorResult = &utils.CursorPaginatedResult{Data: []models.User{}, Meta: utils.CursorMeta{PerPage: params.PerPage}}
+		}
+		result = cursorResult
+	} else {
+		var paginatedResult *utils.PaginatedResult
+		paginatedResult, dbErr = h.userService.GetAllUsersPaginated(params, filter, scope)
+		if dbErr != nil {
+			paginatedResult = &utils.PaginatedResult{
+				Data: []models.User{},
+				Meta: utils.PaginationMeta{
+					CurrentPage: params.Page, PerPage: params.PerPage, TotalItems: 0, TotalPages: 0,
+				},
+			}
+		}
+		result = paginatedResult
+	}
+	if dbErr != nil {
+		utils.Log.Error("Kullanıcı listesi DB Hatası", zap.Error(dbErr))
+	}
+
+	// Aynı uç nokta, JSON isteyen istemcilere sonucu sayfa bilgileriyle birlikte JSON olarak döner.
+	if c.Accepts(fiber.MIMETextHTML, fiber.MIMEApplicationJSON) == fiber.MIMEApplicationJSON {
+		if dbErr != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Kullanıcılar getirilirken bir hata oluştu."})
+		}
+		return c.JSON(result)
+	}
 
 	renderData := fiber.Map{
-		"Title":     "Kullanıcılar",
-		"CsrfToken": c.Locals("csrf"),
-		"Result":    paginatedResult,
-		"Params":    params,
-		"Success":   flashData.Success,
-		"Error":     flashData.Error,
+		"Title":         "Kullanıcılar",
+		"CsrfToken":     c.Locals("csrf"),
+		"Result":        result,
+		"CursorMode":    cursorMode,
+		"Params":        params,
+		"Filter":        filter,
+		"FilterQuery":   template.URL(filterQuery),
+		"ScopeAllTeams": scope.AllTeams,
+		"Teams":         h.allTeams(),
+		"Success":       flashData.Success,
+		"Error":         flashData.Error,
 	}
 
 	if dbErr != nil {
 		dbErrMsg := "Kullanıcılar getirilirken bir hata oluştu."
-		utils.Log.Error("Kullanıcı listesi DB Hatası", zap.Error(dbErr))
 		if existingErr, ok := renderData["Error"].(string); ok && existingErr != "" {
 			renderData["Error"] = existingErr + " | " + dbErrMsg
 		} else {
 			renderData["Error"] = dbErrMsg
 		}
-		renderData["Result"] = &utils.PaginatedResult{
-			Data: []models.User{},
-			Meta: utils.PaginationMeta{
-				CurrentPage: params.Page, PerPage: params.PerPage, TotalItems: 0, TotalPages: 0,
-			},
-		}
 	}
 
 	return c.Render("dashboard/users/dashboard_users_list", renderData, "layouts/dashboard_layout")
@@ -91,9 +157,13 @@ func (h *UserHandler) ShowCreateUser(c *fiber.Ctx) error {
 	}
 
 	mapData := fiber.Map{
-		"Title":     "Yeni Kullanıcı Ekle",
-		"CsrfToken": c.Locals("csrf"),
-		"Success":   flashData.Success,
+		"Title":          "Yeni Kullanıcı Ekle",
+		"CsrfToken":      c.Locals("csrf"),
+		"Roles":          h.allRoles(),
+		"Teams":          h.allTeams(),
+		"ScopeAllTeams":  h.actorScope(c).AllTeams,
+		"CanAssignRoles": h.canAssignRoles(c),
+		"Success":        flashData.Success,
 	}
 
 	combinedError := flashData.Error
@@ -119,15 +189,25 @@ func (h *UserHandler) CreateUser(c *fiber.Ctx) error {
 		Status   string `form:"status"`
 		Type     string `form:"type"`
 		TeamID   string `form:"team_id"`
+		Email    string `form:"email"`
+		Phone    string `form:"phone"`
+		Mode     string `form:"mode"`
 	}
 	var req Request
+	var fieldErrors map[string][]string
+	scope := h.actorScope(c)
 
 	renderError := func(errorMsg string, statusCode int, formData Request) error {
 		mapData := fiber.Map{
-			"Title":     "Yeni Kullanıcı Ekle",
-			"CsrfToken": c.Locals("csrf"),
-			"Error":     errorMsg,
-			"FormData":  formData,
+			"Title":          "Yeni Kullanıcı Ekle",
+			"CsrfToken":      c.Locals("csrf"),
+			"Error":          errorMsg,
+			"FieldErrors":    fieldErrors,
+			"FormData":       formData,
+			"Roles":          h.allRoles(),
+			"Teams":          h.allTeams(),
+			"ScopeAllTeams":  scope.AllTeams,
+			"CanAssignRoles": h.canAssignRoles(c),
 		}
 		return c.Status(statusCode).Render("dashboard/users/dashboard_users_create", mapData, "layouts/dashboard_layout")
 	}
@@ -137,7 +217,15 @@ func (h *UserHandler) CreateUser(c *fiber.Ctx) error {
 		return renderError("Geçersiz veri formatı veya eksik alanlar.", fiber.StatusBadRequest, req)
 	}
 
-	if req.Name == "" || req.Account == "" || req.Password == "" || req.Type == "" {
+	// Davet modunda şifreyi yönetici değil, kullanıcı davet bağlantısıyla kendisi belirler.
+	invite := req.Mode != createModePassword
+	if invite {
+		req.Mode = createModeInvite
+		req.Password = ""
+		if req.Name == "" || req.Account == "" || req.Email == "" || req.Type == "" {
+			return renderError("Davet için Ad, Hesap Adı, E-posta ve Kullanıcı Tipi alanları zorunludur.", fiber.StatusBadRequest, req)
+		}
+	} else if req.Name == "" || req.Account == "" || req.Password == "" || req.Type == "" {
 		return renderError("Ad, Hesap Adı, Şifre ve Kullanıcı Tipi alanları zorunludur.", fiber.StatusBadRequest, req)
 	}
 
@@ -147,16 +235,57 @@ func (h *UserHandler) CreateUser(c *fiber.Ctx) error {
 		Name:     req.Name,
 		Account:  req.Account,
 		Password: req.Password,
+		Email:    req.Email,
+		Phone:    req.Phone,
 		Status:   status,
 		Type:     models.UserType(req.Type),
+		TeamID:   parseOptionalID(req.TeamID),
+		Roles:    selectedRoles(c),
+	}
+
+	// Takımla sınırlı yöneticiler yalnızca kendi takımlarına, yerleşik ajan rolüyle kullanıcı ekleyebilir.
+	if !scope.AllTeams {
+		if scope.TeamID == nil {
+			return renderError(services.ErrUserScopeNoTeam.Error(), fiber.StatusForbidden, req)
+		}
+		user.TeamID = scope.TeamID
+		user.Type = models.Panel
+	}
+	if !h.canAssignRoles(c) {
+		user.Roles = nil
 	}
 
-	if err := h.userService.CreateUser(&user); err != nil {
+	actorID, _ := utils.CurrentUserID(c)
+	var err error
+	if invite {
+		err = h.userService.InviteUser(&user, actorID)
+	} else {
+		err = h.userService.CreateUser(actorID, &user)
+	}
+	if err != nil {
+		var policyErr *passwordpolicy.PolicyError
+		if errors.As(err, &policyErr) {
+			fieldErrors = policyErr.FieldMessages()
+			return renderError("Şifre, kullanıcı tipinin şifre politikasına uymuyor.", fiber.StatusBadRequest, req)
+		}
+		switch err {
+		case services.ErrUserInvalidEmail, services.ErrUserInvalidPhone, services.ErrInvitationEmailRequired:
+			return renderError(err.Error(), fiber.StatusBadRequest, req)
+		case services.ErrRoleAssignForbidden, services.ErrRoleGrantExceeds:
+			return renderError(err.Error(), fiber.StatusForbidden, req)
+		case services.ErrInvitationFailed:
+			_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Kullanıcı oluşturuldu ancak davet gönderilemedi. Daveti listeden yeniden gönderebilirsiniz.")
+			return c.Redirect("/dashboard/users", fiber.StatusFound)
+		}
 		utils.Log.Error("Kullanıcı oluşturulamadı (Servis Hatası)", zap.String("account", req.Account), zap.Error(err))
 		return renderError("Kullanıcı oluşturulamadı: "+err.Error(), fiber.StatusInternalServerError, req)
 	}
 
-	_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, "Kullanıcı başarıyla oluşturuldu.")
+	if invite {
+		_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, "Kullanıcı oluşturuldu ve "+user.Email+" adresine davet gönderildi.")
+	} else {
+		_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, "Kullanıcı başarıyla oluşturuldu.")
+	}
 	return c.Redirect("/dashboard/users", fiber.StatusFound)
 }
 
@@ -168,6 +297,9 @@ func (h *UserHandler) ShowUpdateUser(c *fiber.Ctx) error {
 		return c.Redirect("/dashboard/users", fiber.StatusSeeOther)
 	}
 	userID := uint(id)
+	if !h.authorizeTarget(c, userID) {
+		return c.Redirect("/dashboard/users", fiber.StatusSeeOther)
+	}
 
 	user, err := h.userService.GetUserByID(userID)
 	if err != nil {
@@ -191,10 +323,16 @@ func (h *UserHandler) ShowUpdateUser(c *fiber.Ctx) error {
 	}
 
 	mapData := fiber.Map{
-		"Title":     "Kullanıcı Düzenle",
-		"User":      user,
-		"CsrfToken": c.Locals("csrf"),
-		"Success":   flashData.Success,
+		"Title":          "Kullanıcı Düzenle",
+		"User":           user,
+		"Sessions":       h.userSessions(userID),
+		"Roles":          h.allRoles(),
+		"Teams":          h.allTeams(),
+		"SelectedTeamID": selectedTeamID(user),
+		"ScopeAllTeams":  h.actorScope(c).AllTeams,
+		"CanAssignRoles": h.canAssignRoles(c),
+		"CsrfToken":      c.Locals("csrf"),
+		"Success":        flashData.Success,
 	}
 
 	combinedError := flashData.Error
@@ -220,6 +358,9 @@ func (h *UserHandler) UpdateUser(c *fiber.Ctx) error {
 		return c.Redirect("/dashboard/users", fiber.StatusSeeOther)
 	}
 	userID := uint(id)
+	if !h.authorizeTarget(c, userID) {
+		return c.Redirect("/dashboard/users", fiber.StatusSeeOther)
+	}
 	redirectPathOnSuccess := "/dashboard/users"
 
 	type Request struct {
@@ -228,17 +369,29 @@ func (h *UserHandler) UpdateUser(c *fiber.Ctx) error {
 		Password string `form:"password"`
 		Status   string `form:"status"`
 		Type     string `form:"type"`
+		TeamID   string `form:"team_id"`
+		Email    string `form:"email"`
+		Phone    string `form:"phone"`
 	}
 	var req Request
+	var fieldErrors map[string][]string
+	scope := h.actorScope(c)
 
 	renderError := func(errorMsg string, statusCode int, formData Request) error {
 		user, _ := h.userService.GetUserByID(userID)
 		mapData := fiber.Map{
-			"Title":     "Kullanıcı Düzenle",
-			"CsrfToken": c.Locals("csrf"),
-			"Error":     errorMsg,
-			"User":      user,
-			"FormData":  formData,
+			"Title":          "Kullanıcı Düzenle",
+			"CsrfToken":      c.Locals("csrf"),
+			"Error":          errorMsg,
+			"FieldErrors":    fieldErrors,
+			"User":           user,
+			"Sessions":       h.userSessions(userID),
+			"Roles":          h.allRoles(),
+			"Teams":          h.allTeams(),
+			"SelectedTeamID": selectedTeamID(user),
+			"ScopeAllTeams":  scope.AllTeams,
+			"CanAssignRoles": h.canAssignRoles(c),
+			"FormData":       formData,
 		}
 		return c.Status(statusCode).Render("dashboard/users/dashboard_users_update", mapData, "layouts/dashboard_layout")
 	}
@@ -257,17 +410,45 @@ func (h *UserHandler) UpdateUser(c *fiber.Ctx) error {
 	userUpdateData := &models.User{
 		Name:    req.Name,
 		Account: req.Account,
+		Email:   req.Email,
+		Phone:   req.Phone,
 		Status:  status,
 		Type:    models.UserType(req.Type),
+		TeamID:  parseOptionalID(req.TeamID),
+		Roles:   selectedRoles(c),
+	}
+	// Takımla sınırlı yöneticiler kullanıcının takımını, tipini ve rollerini değiştiremez.
+	if !scope.AllTeams {
+		existing, err := h.userService.GetUserByID(userID)
+		if err != nil {
+			_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Güncellenecek kullanıcı bulunamadı.")
+			return c.Redirect(redirectPathOnSuccess, fiber.StatusSeeOther)
+		}
+		userUpdateData.TeamID = existing.TeamID
+		userUpdateData.Type = existing.Type
+	}
+	if !h.canAssignRoles(c) {
+		userUpdateData.Roles = nil
 	}
 	if req.Password != "" {
 		userUpdateData.Password = req.Password
 	}
 
-	if err := h.userService.UpdateUser(userID, userUpdateData); err != nil {
+	actorID, _ := utils.CurrentUserID(c)
+	if err := h.userService.UpdateUser(actorID, userID, userUpdateData); err != nil {
 		errMsg := "Kullanıcı güncellenemedi: " + err.Error()
 		statusCode := fiber.StatusInternalServerError
 
+		if msg, ok := safeguardErrorMessage(err); ok {
+			return renderError(msg, fiber.StatusForbidden, req)
+		}
+
+		var policyErr *passwordpolicy.PolicyError
+		if errors.As(err, &policyErr) {
+			fieldErrors = policyErr.FieldMessages()
+			return renderError("Şifre, kullanıcı tipinin şifre politikasına uymuyor.", fiber.StatusBadRequest, req)
+		}
+
 		if err == services.ErrUserServiceUserNotFound {
 			utils.Log.Warn("Kullanıcı güncelleme: Kullanıcı bulunamadı (Servis hatası)", zap.Uint("user_id", userID))
 			errMsg = "Güncellenecek kullanıcı bulunamadı."
@@ -277,16 +458,54 @@ func (h *UserHandler) UpdateUser(c *fiber.Ctx) error {
 			statusCode = fiber.StatusBadRequest
 		} else if err == services.ErrPasswordUpdateFailed || err == services.ErrPasswordHashingFailed {
 			statusCode = fiber.StatusBadRequest
+		} else if err == services.ErrUserInvalidEmail || err == services.ErrUserInvalidPhone {
+			return renderError(err.Error(), fiber.StatusBadRequest, req)
+		} else if err == services.ErrRoleAssignForbidden || err == services.ErrRoleGrantExceeds {
+			return renderError(err.Error(), fiber.StatusForbidden, req)
 		}
 
 		utils.Log.Error("Kullanıcı güncelleme: Handler'da servis hatası yakalandı", zap.Uint("user_id", userID), zap.Error(err))
 		return renderError(errMsg, statusCode, req)
 	}
 
+	if req.Password != "" {
+		h.securityEventService.Record(&models.SecurityEvent{
+			UserID:    &userID,
+			Account:   userUpdateData.Account,
+			Type:      models.EventPasswordChangedByAdmin,
+			Success:   true,
+			IP:        c.IP(),
+			UserAgent: c.Get(fiber.HeaderUserAgent),
+		})
+	}
+
+	// Profil resmi, diğer bilgiler kaydedildikten sonra işlenir; hatası güncellemeyi geri almaz.
+	if c.FormValue("remove_avatar") == "true" {
+		if err := h.userService.RemoveAvatar(userID); err != nil {
+			_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Kullanıcı güncellendi ancak profil resmi kaldırılamadı: "+err.Error())
+			return c.Redirect(fmt.Sprintf("/dashboard/users/update/%d", userID), fiber.StatusSeeOther)
+		}
+	} else if fileHeader, err := c.FormFile("avatar"); err == nil {
+		if err := h.saveAvatar(userID, fileHeader); err != nil {
+			_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Kullanıcı güncellendi ancak profil resmi kaydedilemedi: "+err.Error())
+			return c.Redirect(fmt.Sprintf("/dashboard/users/update/%d", userID), fiber.StatusSeeOther)
+		}
+	}
+
 	_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, "Kullanıcı başarıyla güncellendi.")
 	return c.Redirect(redirectPathOnSuccess, fiber.StatusFound)
 }
 
+func (h *UserHandler) saveAvatar(userID uint, fileHeader *multipart.FileHeader) error {
+	file, err := fileHeader.Open()
+	if err != nil {
+		utils.Log.Warn("Profil resmi dosyası açılamadı", zap.Uint("user_id", userID), zap.Error(err))
+		return avatar.ErrInvalidImage
+	}
+	defer file.Close()
+	return h.userService.SetAvatar(userID, file)
+}
+
 func (h *UserHandler) DeleteUser(c *fiber.Ctx) error {
 	id, err := c.ParamsInt("id")
 	if err != nil || id <= 0 {
@@ -295,10 +514,16 @@ func (h *UserHandler) DeleteUser(c *fiber.Ctx) error {
 		return c.Redirect("/dashboard/users", fiber.StatusSeeOther)
 	}
 	userID := uint(id)
+	if !h.authorizeTarget(c, userID) {
+		return c.Redirect("/dashboard/users", fiber.StatusSeeOther)
+	}
 
-	if err := h.userService.DeleteUser(userID); err != nil {
+	actorID, _ := utils.CurrentUserID(c)
+	if err := h.userService.DeleteUser(actorID, userID); err != nil {
 		var errMsg string
-		if err == services.ErrUserServiceUserNotFound {
+		if msg, ok := safeguardErrorMessage(err); ok {
+			errMsg = msg
+		} else if err == services.ErrUserServiceUserNotFound {
 			utils.Log.Warn("Kullanıcı silme: Kullanıcı bulunamadı", zap.Uint("user_id", userID))
 			errMsg = "Silinecek kullanıcı bulunamadı."
 		} else {
@@ -312,3 +537,233 @@ func (h *UserHandler) DeleteUser(c *fiber.Ctx) error {
 	_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, "Kullanıcı başarıyla silindi.")
 	return c.Redirect("/dashboard/users", fiber.StatusFound)
 }
+
+func (h *UserHandler) UnlockUser(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		utils.Log.Warn("Kullanıcı kilidi açma: Geçersiz ID parametresi", zap.String("param", c.Params("id")))
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Geçersiz kullanıcı ID'si.")
+		return c.Redirect("/dashboard/users", fiber.StatusSeeOther)
+	}
+	userID := uint(id)
+	if !h.authorizeTarget(c, userID) {
+		return c.Redirect("/dashboard/users", fiber.StatusSeeOther)
+	}
+
+	if err := h.userService.UnlockUser(userID); err != nil {
+		var errMsg string
+		if err == services.ErrUserServiceUserNotFound {
+			errMsg = "Kilidi açılacak kullanıcı bulunamadı."
+		} else {
+			utils.Log.Error("Kullanıcı kilidi açma: Servis hatası", zap.Uint("user_id", userID), zap.Error(err))
+			errMsg = "Kullanıcı kilidi açılamadı: " + err.Error()
+		}
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, errMsg)
+		return c.Redirect("/dashboard/users", fiber.StatusSeeOther)
+	}
+
+	_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, "Kullanıcı kilidi başarıyla kaldırıldı.")
+	return c.Redirect(fmt.Sprintf("/dashboard/users/update/%d", userID), fiber.StatusFound)
+}
+
+func (h *UserHandler) ResetTwoFactor(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		utils.Log.Warn("2FA sıfırlama: Geçersiz ID parametresi", zap.String("param", c.Params("id")))
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Geçersiz kullanıcı ID'si.")
+		return c.Redirect("/dashboard/users", fiber.StatusSeeOther)
+	}
+	userID := uint(id)
+	if !h.authorizeTarget(c, userID) {
+		return c.Redirect("/dashboard/users", fiber.StatusSeeOther)
+	}
+
+	if err := h.twoFactorService.ResetForUser(userID); err != nil {
+		var errMsg string
+		if err == services.ErrUserNotFound {
+			errMsg = "Kullanıcı bulunamadı."
+		} else {
+			utils.Log.Error("2FA sıfırlama: Servis hatası", zap.Uint("user_id", userID), zap.Error(err))
+			errMsg = "İki adımlı doğrulama sıfırlanamadı: " + err.Error()
+		}
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, errMsg)
+		return c.Redirect("/dashboard/users", fiber.StatusSeeOther)
+	}
+
+	_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, "Kullanıcının iki adımlı doğrulaması sıfırlandı.")
+	return c.Redirect(fmt.Sprintf("/dashboard/users/update/%d", userID), fiber.StatusFound)
+}
+
+func (h *UserHandler) LogoutEverywhere(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		utils.Log.Warn("Tüm oturumları sonlandırma: Geçersiz ID parametresi", zap.String("param", c.Params("id")))
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Geçersiz kullanıcı ID'si.")
+		return c.Redirect("/dashboard/users", fiber.StatusSeeOther)
+	}
+	userID := uint(id)
+	if !h.authorizeTarget(c, userID) {
+		return c.Redirect("/dashboard/users", fiber.StatusSeeOther)
+	}
+
+	count, err := h.userService.LogoutEverywhere(userID)
+	if err != nil {
+		var errMsg string
+		if err == services.ErrUserServiceUserNotFound {
+			errMsg = "Kullanıcı bulunamadı."
+		} else {
+			utils.Log.Error("Tüm oturumları sonlandırma: Servis hatası", zap.Uint("user_id", userID), zap.Error(err))
+			errMsg = "Oturumlar sonlandırılamadı: " + err.Error()
+		}
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, errMsg)
+		return c.Redirect("/dashboard/users", fiber.StatusSeeOther)
+	}
+
+	_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, fmt.Sprintf("Kullanıcının %d oturumu sonlandırıldı.", count))
+	return c.Redirect(fmt.Sprintf("/dashboard/users/update/%d", userID), fiber.StatusFound)
+}
+
+func (h *UserHandler) RevokeUserSession(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		utils.Log.Warn("Oturum sonlandırma: Geçersiz ID parametresi", zap.String("param", c.Params("id")))
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Geçersiz kullanıcı ID'si.")
+		return c.Redirect("/dashboard/users", fiber.StatusSeeOther)
+	}
+	userID := uint(id)
+	if !h.authorizeTarget(c, userID) {
+		return c.Redirect("/dashboard/users", fiber.StatusSeeOther)
+	}
+	redirectPath := fmt.Sprintf("/dashboard/users/update/%d", userID)
+
+	sessionID, err := c.ParamsInt("sessionId")
+	if err != nil || sessionID <= 0 {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Geçersiz oturum.")
+		return c.Redirect(redirectPath, fiber.StatusSeeOther)
+	}
+
+	if err := h.sessionService.Revoke(userID, uint(sessionID)); err != nil {
+		errMsg := "Oturum sonlandırılamadı."
+		if err == services.ErrSessionNotFound {
+			errMsg = "Oturum bulunamadı veya zaten sonlandırılmış."
+		}
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, errMsg)
+		return c.Redirect(redirectPath, fiber.StatusSeeOther)
+	}
+
+	_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, "Oturum sonlandırıldı.")
+	return c.Redirect(redirectPath, fiber.StatusFound)
+}
+
+func (h *UserHandler) userSessions(userID uint) []models.UserSession {
+	sessions, err := h.sessionService.ListActive(userID)
+	if err != nil {
+		return nil
+	}
+	return sessions
+}
+
+func (h *UserHandler) allRoles() []models.Role {
+	roles, err := h.roleService.GetRoles()
+	if err != nil {
+		return nil
+	}
+	return roles
+}
+
+// selectedRoles, formda işaretlenen rolleri yalnızca ID'leriyle döner; boş liste yerleşik rolün atanacağı anlamına gelir.
+func selectedRoles(c *fiber.Ctx) []models.Role {
+	roles := []models.Role{}
+	for _, id := range formIDs(c, "role_ids") {
+		roles = append(roles, models.Role{ID: id})
+	}
+	return roles
+}
+
+func (h *UserHandler) allTeams() []models.Team {
+	teams, err := h.teamService.GetTeams()
+	if err != nil {
+		return nil
+	}
+	return teams
+}
+
+// actorScope, oturumdaki kullanıcının görebileceği ve yönetebileceği kullanıcı kapsamını döner.
+func (h *UserHandler) actorScope(c *fiber.Ctx) services.UserScope {
+	actorID, ok := utils.CurrentUserID(c)
+	if !ok {
+		return services.UserScope{}
+	}
+	return h.userService.ResolveScope(actorID)
+}
+
+// canAssignRoles, oturumdaki kullanıcının formda rol seçebilip seçemeyeceğini döner.
+func (h *UserHandler) canAssignRoles(c *fiber.Ctx) bool {
+	actorID, ok := utils.CurrentUserID(c)
+	if !ok {
+		return false
+	}
+	return h.actorScope(c).AllTeams && h.roleService.HasPermission(actorID, models.PermRolesManage)
+}
+
+// safeguardErrorMessage, korumalı kullanıcı, kendi hesabı ve son sistem kullanıcısı kurallarının
+// ihlalinde kullanıcıya gösterilecek açıklamayı döner.
+func safeguardErrorMessage(err error) (string, bool) {
+	switch err {
+	case services.ErrUserProtected:
+		return "Bu kullanıcı korumalı sistem kullanıcısıdır; başka kullanıcılar tarafından değiştirilemez veya silinemez.", true
+	case services.ErrUserSelfDelete:
+		return "Kendi hesabınızı silemezsiniz. Gerekirse başka bir sistem kullanıcısından silmesini isteyin.", true
+	case services.ErrUserSelfDeactivate:
+		return "Kendi hesabınızı pasif yapamazsınız; aksi halde oturumunuz kapanır ve yeniden giriş yapamazsınız.", true
+	case services.ErrUserSelfDemote:
+		return "Kendi kullanıcı tipinizi sistemden başka bir tipe değiştiremez veya Sistem Yöneticisi rolünüzü kaldıramazsınız. Bu değişikliği başka bir sistem yöneticisi yapmalıdır.", true
+	case services.ErrLastSystemUser:
+		return "Bu işlem giriş yapabilen son sistem yöneticisini kaldıracağı için engellendi. Önce başka bir aktif kullanıcıya Sistem Yöneticisi rolü verin.", true
+	case services.ErrSystemUserCheckFailed:
+		return "Sistem yöneticileri denetlenemediği için işlem yapılmadı. Lütfen tekrar deneyin.", true
+	}
+	return "", false
+}
+
+// authorizeTarget, hedef kullanıcı oturumdaki kullanıcının kapsamı dışındaysa uyarı bırakıp false döner.
+func (h *UserHandler) authorizeTarget(c *fiber.Ctx, userID uint) bool {
+	return h.authorizeLoadedTarget(c, userID, h.userService.GetUserByID)
+}
+
+// authorizeDeletedTarget, authorizeTarget'ın çöp kutusundaki kullanıcılar için olan karşılığıdır.
+func (h *UserHandler) authorizeDeletedTarget(c *fiber.Ctx, userID uint) bool {
+	return h.authorizeLoadedTarget(c, userID, h.userService.GetDeletedUserByID)
+}
+
+func (h *UserHandler) authorizeLoadedTarget(c *fiber.Ctx, userID uint, load func(uint) (*models.User, error)) bool {
+	scope := h.actorScope(c)
+	if scope.AllTeams {
+		return true
+	}
+
+	target, err := load(userID)
+	if err == nil && h.userService.CanManage(scope, target) {
+		return true
+	}
+
+	utils.Log.Warn("Kapsam dışındaki kullanıcı üzerinde işlem engellendi", zap.Uint("target_user_id", userID))
+	_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Bu kullanıcı üzerinde işlem yetkiniz yok.")
+	return false
+}
+
+func parseOptionalID(value string) *uint {
+	id, err := strconv.ParseUint(value, 10, 64)
+	if err != nil || id == 0 {
+		return nil
+	}
+	result := uint(id)
+	return &result
+}
+
+func selectedTeamID(user *models.User) uint {
+	if user == nil || user.TeamID == nil {
+		return 0
+	}
+	return *user.TeamID
+}
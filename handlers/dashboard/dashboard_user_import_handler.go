@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"encoding/json"
+
+	"zatrano/services"
+	"zatrano/spreadsheet"
+	"zatrano/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+const usersImportView = "dashboard/users/dashboard_users_import"
+
+// importTemplateCSV, içe aktarma ekranından indirilebilen örnek dosyadır.
+const importTemplateCSV = "name,account,type,status,password\r\n" +
+	"Ayşe Yılmaz,ayse.yilmaz,panel,aktif,\r\n"
+
+func (h *UserHandler) ShowImportUsers(c *fiber.Ctx) error {
+	return h.renderImport(c, fiber.StatusOK, fiber.Map{})
+}
+
+// PreviewImportUsers, yüklenen dosyayı hiçbir kayıt oluşturmadan doğrular ve satır bazında hata raporu gösterir.
+func (h *UserHandler) PreviewImportUsers(c *fiber.Ctx) error {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return h.renderImport(c, fiber.StatusBadRequest, fiber.Map{"Error": "Lütfen içe aktarılacak bir CSV veya XLSX dosyası seçin."})
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		utils.Log.Error("İçe aktarma: Yüklenen dosya açılamadı", zap.Error(err))
+		return h.renderImport(c, fiber.StatusBadRequest, fiber.Map{"Error": spreadsheet.ErrUnreadableFile.Error()})
+	}
+	defer file.Close()
+
+	rows, err := spreadsheet.ReadRows(fileHeader.Filename, file)
+	if err != nil {
+		return h.renderImport(c, fiber.StatusBadRequest, fiber.Map{"Error": "Dosya okunamadı: " + err.Error()})
+	}
+
+	importRows, err := services.ParseImportRows(rows)
+	if err != nil {
+		return h.renderImport(c, fiber.StatusBadRequest, fiber.Map{"Error": "Dosya işlenemedi: " + err.Error()})
+	}
+
+	report, err := h.userService.PreviewImport(importRows, h.actorScope(c))
+	if err != nil {
+		return h.renderImport(c, fiber.StatusBadRequest, fiber.Map{"Error": err.Error()})
+	}
+
+	payload, err := json.Marshal(importRows)
+	if err != nil {
+		utils.Log.Error("İçe aktarma: Önizleme verisi hazırlanamadı", zap.Error(err))
+		return h.renderImport(c, fiber.StatusInternalServerError, fiber.Map{"Error": "Önizleme hazırlanamadı."})
+	}
+
+	return h.renderImport(c, fiber.StatusOK, fiber.Map{
+		"Report":   report,
+		"FileName": fileHeader.Filename,
+		"Payload":  string(payload),
+	})
+}
+
+// CommitImportUsers, önizlemesi onaylanan satırları yeniden doğrulayarak kullanıcıları oluşturur.
+func (h *UserHandler) CommitImportUsers(c *fiber.Ctx) error {
+	var importRows []services.ImportRow
+	if err := json.Unmarshal([]byte(c.FormValue("payload")), &importRows); err != nil || len(importRows) == 0 {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "İçe aktarma verisi okunamadı, lütfen dosyayı tekrar yükleyin.")
+		return c.Redirect("/dashboard/users/import", fiber.StatusSeeOther)
+	}
+	if len(importRows) > services.MaxImportRows {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, services.ErrImportTooManyRows.Error())
+		return c.Redirect("/dashboard/users/import", fiber.StatusSeeOther)
+	}
+
+	actorID, _ := utils.CurrentUserID(c)
+	result, err := h.userService.CommitImport(actorID, importRows, h.actorScope(c))
+	if err != nil {
+		return h.renderImport(c, fiber.StatusBadRequest, fiber.Map{"Error": "Kullanıcılar içe aktarılamadı: " + err.Error()})
+	}
+
+	return h.renderImport(c, fiber.StatusOK, fiber.Map{"Result": result})
+}
+
+func (h *UserHandler) DownloadImportTemplate(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+	c.Set(fiber.HeaderContentDisposition, `attachment; filename="kullanici_aktarim_sablonu.csv"`)
+	return c.SendString(importTemplateCSV)
+}
+
+func (h *UserHandler) renderImport(c *fiber.Ctx, status int, data fiber.Map) error {
+	data["Title"] = "Kullanıcıları İçe Aktar"
+	data["CsrfToken"] = c.Locals("csrf")
+	data["MaxRows"] = services.MaxImportRows
+	if _, ok := data["Error"]; !ok {
+		flashData, flashErr := utils.GetFlashMessages(c)
+		if flashErr != nil {
+			utils.Log.Warn("İçe aktarma: Flash mesajları alınamadı", zap.Error(flashErr))
+		}
+		data["Success"] = flashData.Success
+		data["Error"] = flashData.Error
+	}
+	return c.Status(status).Render(usersImportView, data, "layouts/dashboard_layout")
+}
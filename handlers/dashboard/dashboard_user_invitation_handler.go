@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"zatrano/services"
+	"zatrano/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// Yeni kullanıcı formundaki oluşturma modları.
+const (
+	createModeInvite   = "invite"
+	createModePassword = "password"
+)
+
+func (h *UserHandler) ResendInvitation(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		utils.Log.Warn("Davet yeniden gönderme: Geçersiz ID parametresi", zap.String("param", c.Params("id")))
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Geçersiz kullanıcı ID'si.")
+		return c.Redirect("/dashboard/users", fiber.StatusSeeOther)
+	}
+	userID := uint(id)
+	if !h.authorizeTarget(c, userID) {
+		return c.Redirect("/dashboard/users", fiber.StatusSeeOther)
+	}
+
+	actorID, _ := utils.CurrentUserID(c)
+	if err := h.invitationService.Send(userID, actorID); err != nil {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, invitationErrorMessage(err, userID))
+		return c.Redirect("/dashboard/users", fiber.StatusSeeOther)
+	}
+
+	_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, "Davet yeniden gönderildi; önceki davet bağlantısı artık geçersiz.")
+	return c.Redirect("/dashboard/users", fiber.StatusSeeOther)
+}
+
+func (h *UserHandler) RevokeInvitation(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		utils.Log.Warn("Davet geri alma: Geçersiz ID parametresi", zap.String("param", c.Params("id")))
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Geçersiz kullanıcı ID'si.")
+		return c.Redirect("/dashboard/users", fiber.StatusSeeOther)
+	}
+	userID := uint(id)
+	if !h.authorizeTarget(c, userID) {
+		return c.Redirect("/dashboard/users", fiber.StatusSeeOther)
+	}
+
+	if err := h.invitationService.Revoke(userID); err != nil {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, invitationErrorMessage(err, userID))
+		return c.Redirect("/dashboard/users", fiber.StatusSeeOther)
+	}
+
+	_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, "Davet geri alındı. Kullanıcı yeni bir davet gönderilene kadar hesabını etkinleştiremez.")
+	return c.Redirect("/dashboard/users", fiber.StatusSeeOther)
+}
+
+func invitationErrorMessage(err error, userID uint) string {
+	switch err {
+	case services.ErrUserServiceUserNotFound:
+		return "Kullanıcı bulunamadı."
+	case services.ErrInvitationNotPending, services.ErrInvitationEmailRequired:
+		return "Davet işlemi yapılamadı: " + err.Error() + "."
+	}
+	utils.Log.Error("Davet işlemi: Servis hatası", zap.Uint("user_id", userID), zap.Error(err))
+	return "Davet işlemi yapılamadı: " + err.Error()
+}
@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"zatrano/models"
+	"zatrano/services"
+	"zatrano/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+const userTrashPath = "/dashboard/users/trash"
+
+// ListDeletedUsers, silinmiş (çöp kutusundaki) kullanıcıları listeler.
+func (h *UserHandler) ListDeletedUsers(c *fiber.Ctx) error {
+	flashData, flashErr := utils.GetFlashMessages(c)
+	if flashErr != nil {
+		utils.Log.Warn("Silinen kullanıcılar: Flash mesajları alınamadı", zap.Error(flashErr))
+	}
+
+	var params utils.ListParams
+	if err := c.QueryParser(&params); err != nil {
+		utils.Log.Warn("Silinen kullanıcılar: Query parametreleri parse edilemedi, varsayılanlar kullanılıyor.", zap.Error(err))
+		params = utils.ListParams{}
+	}
+	if params.Page <= 0 {
+		params.Page = utils.DefaultPage
+	}
+	if params.PerPage <= 0 || params.PerPage > utils.MaxPerPage {
+		params.PerPage = utils.DefaultPerPage
+	}
+	if params.SortBy == "" {
+		params.SortBy = "deleted_at"
+	}
+	if params.OrderBy == "" {
+		params.OrderBy = utils.DefaultOrderBy
+	}
+
+	scope := h.actorScope(c)
+	paginatedResult, dbErr := h.userService.GetDeletedUsersPaginated(params, utils.UserFilter{}, scope)
+	if dbErr != nil {
+		utils.Log.Error("Silinen kullanıcılar listesi DB Hatası", zap.Error(dbErr))
+		flashData.Error = "Silinen kullanıcılar getirilirken bir hata oluştu."
+		paginatedResult = &utils.PaginatedResult{
+			Data: []models.User{},
+			Meta: utils.PaginationMeta{CurrentPage: params.Page, PerPage: params.PerPage},
+		}
+	}
+
+	return c.Render("dashboard/users/dashboard_users_trash", fiber.Map{
+		"Title":         "Silinen Kullanıcılar",
+		"CsrfToken":     c.Locals("csrf"),
+		"Result":        paginatedResult,
+		"Params":        params,
+		"RetentionDays": h.userService.GetTrashRetentionDays(),
+		"Success":       flashData.Success,
+		"Error":         flashData.Error,
+	}, "layouts/dashboard_layout")
+}
+
+func (h *UserHandler) RestoreUser(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Geçersiz kullanıcı ID'si.")
+		return c.Redirect(userTrashPath, fiber.StatusSeeOther)
+	}
+	userID := uint(id)
+	if !h.authorizeDeletedTarget(c, userID) {
+		return c.Redirect(userTrashPath, fiber.StatusSeeOther)
+	}
+
+	if err := h.userService.RestoreUser(userID); err != nil {
+		errMsg := "Kullanıcı geri yüklenemedi: " + err.Error()
+		if err == services.ErrUserServiceUserNotFound {
+			errMsg = "Geri yüklenecek kullanıcı bulunamadı."
+		}
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, errMsg)
+		return c.Redirect(userTrashPath, fiber.StatusSeeOther)
+	}
+
+	_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, "Kullanıcı geri yüklendi.")
+	return c.Redirect(userTrashPath, fiber.StatusFound)
+}
+
+func (h *UserHandler) PurgeUser(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, "Geçersiz kullanıcı ID'si.")
+		return c.Redirect(userTrashPath, fiber.StatusSeeOther)
+	}
+	userID := uint(id)
+	if !h.authorizeDeletedTarget(c, userID) {
+		return c.Redirect(userTrashPath, fiber.StatusSeeOther)
+	}
+
+	if err := h.userService.PurgeUser(userID); err != nil {
+		errMsg := "Kullanıcı kalıcı olarak silinemedi: " + err.Error()
+		if err == services.ErrUserServiceUserNotFound {
+			errMsg = "Kalıcı olarak silinecek kullanıcı bulunamadı."
+		}
+		_ = utils.SetFlashMessage(c, utils.FlashErrorKey, errMsg)
+		return c.Redirect(userTrashPath, fiber.StatusSeeOther)
+	}
+
+	_ = utils.SetFlashMessage(c, utils.FlashSuccessKey, "Kullanıcı kalıcı olarak silindi.")
+	return c.Redirect(userTrashPath, fiber.StatusFound)
+}
@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"zatrano/services"
+	"zatrano/storage"
+	"zatrano/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type FileHandler struct {
+	service services.IFileService
+}
+
+func NewFileHandler() *FileHandler {
+	return &FileHandler{service: services.NewFileService()}
+}
+
+// Download, depodaki özel bir dosyayı oturumdaki kullanıcının yetkisini denetledikten sonra sunar.
+// FILE_DOWNLOAD_MODE=redirect ise dosya akıtılmaz, kısa ömürlü imzalı adrese yönlendirilir.
+func (h *FileHandler) Download(c *fiber.Ctx) error {
+	viewerID, ok := utils.CurrentUserID(c)
+	if !ok {
+		return c.SendStatus(fiber.StatusUnauthorized)
+	}
+	key, err := fileKey(c)
+	if err != nil {
+		return c.SendStatus(fiber.StatusNotFound)
+	}
+
+	if h.service.RedirectDownloads() {
+		signed, err := h.service.SignedURL(viewerID, key)
+		if err != nil {
+			return c.SendStatus(fileErrorStatus(err))
+		}
+		c.Set(fiber.HeaderCacheControl, "no-store")
+		return c.Redirect(signed, fiber.StatusFound)
+	}
+
+	object, err := h.service.Open(viewerID, key)
+	if err != nil {
+		return c.SendStatus(fileErrorStatus(err))
+	}
+	return sendObject(c, key, object)
+}
+
+// SignedDownload, yerel depo için üretilen imzalı adresleri sunar; imza oturumun yerini tuttuğu için
+// giriş gerektirmez.
+func (h *FileHandler) SignedDownload(c *fiber.Ctx) error {
+	key, err := fileKey(c)
+	if err != nil {
+		return c.SendStatus(fiber.StatusNotFound)
+	}
+	expires := int64(c.QueryInt("expires"))
+
+	object, err := h.service.OpenSigned(key, expires, c.Query("signature"))
+	if err != nil {
+		return c.SendStatus(fileErrorStatus(err))
+	}
+	return sendObject(c, key, object)
+}
+
+func fileKey(c *fiber.Ctx) (string, error) {
+	return url.PathUnescape(c.Params("*"))
+}
+
+func fileErrorStatus(err error) int {
+	switch err {
+	case services.ErrFileForbidden:
+		return fiber.StatusForbidden
+	case services.ErrFileNotFound:
+		return fiber.StatusNotFound
+	}
+	return fiber.StatusInternalServerError
+}
+
+// sendObject, dosyayı belleğe almadan akıtır. Resimler dışındaki dosyalar tarayıcıda çalıştırılmaması için
+// indirme olarak sunulur.
+func sendObject(c *fiber.Ctx, key string, object *storage.Object) error {
+	if !strings.HasPrefix(object.ContentType, "image/") || object.ContentType == "image/svg+xml" {
+		c.Attachment(path.Base(key))
+	}
+	c.Set(fiber.HeaderContentType, object.ContentType)
+	c.Set(fiber.HeaderCacheControl, "private, max-age=3600")
+	c.Set(fiber.HeaderXContentTypeOptions, "nosniff")
+	if !object.ModTime.IsZero() {
+		c.Set(fiber.HeaderLastModified, object.ModTime.UTC().Format(http.TimeFormat))
+	}
+	return c.SendStream(object.Body, int(object.Size))
+}
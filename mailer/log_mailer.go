@@ -0,0 +1,62 @@
+package mailer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"zatrano/utils"
+
+	"go.uber.org/zap"
+)
+
+// LogMailer, geliştirme ortamı için e-postaları göndermek yerine loglar ve isteğe bağlı olarak dosyaya yazar.
+// İçerik tek kullanımlık bağlantılar taşıyabildiği için loga yalnızca alıcı ve konu yazılır.
+type LogMailer struct {
+	from string
+	dir  string
+}
+
+func NewLogMailer(from, dir string) *LogMailer {
+	return &LogMailer{from: from, dir: dir}
+}
+
+func (m *LogMailer) Send(msg Message) error {
+	if len(msg.To) == 0 {
+		return ErrNoRecipients
+	}
+
+	utils.Log.Info("E-posta (log transport)",
+		zap.Strings("to", msg.To),
+		zap.String("subject", msg.Subject),
+		zap.String("file_dir", m.dir),
+	)
+
+	if m.dir == "" {
+		return nil
+	}
+	if err := os.MkdirAll(m.dir, 0o755); err != nil {
+		return err
+	}
+
+	fileName := fmt.Sprintf("%s.eml", time.Now().UTC().Format("20060102T150405.000000000"))
+	content := buildMessage(m.from, msg)
+	return os.WriteFile(filepath.Join(m.dir, fileName), []byte(content), 0o600)
+}
+
+func buildMessage(from string, msg Message) string {
+	var builder strings.Builder
+	builder.WriteString("From: " + from + "\r\n")
+	builder.WriteString("To: " + strings.Join(msg.To, ", ") + "\r\n")
+	builder.WriteString("Subject: " + msg.Subject + "\r\n")
+	builder.WriteString("Date: " + time.Now().UTC().Format(time.RFC1123Z) + "\r\n")
+	builder.WriteString("MIME-Version: 1.0\r\n")
+	builder.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	builder.WriteString("\r\n")
+	builder.WriteString(strings.ReplaceAll(msg.TextBody, "\n", "\r\n"))
+	return builder.String()
+}
+
+var _ Mailer = (*LogMailer)(nil)
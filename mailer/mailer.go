@@ -0,0 +1,21 @@
+package mailer
+
+type MailerError string
+
+func (e MailerError) Error() string {
+	return string(e)
+}
+
+const (
+	ErrNoRecipients MailerError = "e-posta için alıcı belirtilmedi"
+)
+
+type Message struct {
+	To       []string
+	Subject  string
+	TextBody string
+}
+
+type Mailer interface {
+	Send(msg Message) error
+}
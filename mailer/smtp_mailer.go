@@ -0,0 +1,37 @@
+package mailer
+
+import (
+	"mime"
+	"net/smtp"
+	"strconv"
+)
+
+type SMTPMailer struct {
+	host     string
+	port     int
+	username string
+	password string
+	from     string
+}
+
+func NewSMTPMailer(host string, port int, username, password, from string) *SMTPMailer {
+	return &SMTPMailer{host: host, port: port, username: username, password: password, from: from}
+}
+
+func (m *SMTPMailer) Send(msg Message) error {
+	if len(msg.To) == 0 {
+		return ErrNoRecipients
+	}
+
+	var auth smtp.Auth
+	if m.username != "" {
+		auth = smtp.PlainAuth("", m.username, m.password, m.host)
+	}
+
+	encoded := msg
+	encoded.Subject = mime.QEncoding.Encode("utf-8", msg.Subject)
+	address := m.host + ":" + strconv.Itoa(m.port)
+	return smtp.SendMail(address, auth, m.from, msg.To, []byte(buildMessage(m.from, encoded)))
+}
+
+var _ Mailer = (*SMTPMailer)(nil)
@@ -5,8 +5,12 @@ import (
 	"zatrano/utils"
 
 	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
 )
 
+// AuthMiddleware, oturumdaki kullanıcıyı istek başına bir kez yükler ve utils.CurrentUser ile
+// erişilebilmesi için istek bağlamına yazar. Sonraki middleware'ler ve handler'lar kullanıcıyı
+// veritabanından tekrar okumamalıdır.
 func AuthMiddleware(c *fiber.Ctx) error {
 	sess, err := utils.SessionStart(c)
 
@@ -22,12 +26,29 @@ func AuthMiddleware(c *fiber.Ctx) error {
 
 	authService := services.NewAuthService()
 
-	_, err = authService.GetUserProfile(userID)
+	user, err := authService.GetUserProfile(userID)
 
 	if err != nil {
 		_ = sess.Destroy()
 		return c.Redirect("/auth/login")
 	}
 
+	if utils.IsSessionStale(sess, user) {
+		_ = sess.Destroy()
+		return c.Redirect("/auth/login")
+	}
+
+	sessionService := services.NewSessionService()
+	if err := sessionService.Touch(sess.ID(), user.ID); err == services.ErrSessionRevoked {
+		_ = sess.Destroy()
+		return c.Redirect("/auth/login")
+	}
+
+	if err := utils.RefreshSessionUser(sess, user); err != nil {
+		utils.Log.Warn("Oturumdaki kullanıcı bilgileri yenilenemedi", zap.Uint("user_id", user.ID), zap.Error(err))
+	}
+
+	utils.SetCurrentUser(c, user)
+
 	return c.Next()
 }
@@ -21,7 +21,13 @@ func GuestMiddleware(c *fiber.Ctx) error {
 
 	authService := services.NewAuthService()
 	user, err := authService.GetUserProfile(userID)
-	if err != nil {
+	if err != nil || utils.IsSessionStale(sess, user) {
+		_ = sess.Destroy()
+		return c.Next()
+	}
+
+	sessionService := services.NewSessionService()
+	if err := sessionService.Touch(sess.ID(), user.ID); err == services.ErrSessionRevoked {
 		_ = sess.Destroy()
 		return c.Next()
 	}
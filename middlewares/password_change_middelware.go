@@ -0,0 +1,21 @@
+package middlewares
+
+import (
+	"zatrano/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// PasswordChangeMiddleware, şifresini değiştirmesi gereken kullanıcıları şifre değiştirme ekranına yönlendirir.
+func PasswordChangeMiddleware(c *fiber.Ctx) error {
+	sess, err := utils.SessionStart(c)
+	if err != nil {
+		return c.Redirect("/auth/login")
+	}
+
+	if required, ok := sess.Get("password_change_required").(bool); ok && required {
+		return c.Redirect("/auth/change-password")
+	}
+
+	return c.Next()
+}
@@ -0,0 +1,39 @@
+package middlewares
+
+import (
+	"zatrano/services"
+	"zatrano/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// permissionsLocalsKey, aynı istekte birden fazla izin kontrolü yapıldığında izinlerin tekrar okunmaması için kullanılır.
+const permissionsLocalsKey = "permissions"
+
+// RequirePermission, oturumdaki kullanıcının rollerinden birinde verilen izin yoksa isteği reddeder.
+func RequirePermission(permission string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		permissions, ok := c.Locals(permissionsLocalsKey).(map[string]bool)
+		if !ok {
+			userID, found := utils.CurrentUserID(c)
+			if !found {
+				return c.Status(fiber.StatusUnauthorized).SendString("Oturum açılmamış")
+			}
+
+			var err error
+			permissions, err = services.NewRoleService().GetUserPermissions(userID)
+			if err != nil {
+				utils.Log.Error("İzin kontrolü: Kullanıcı izinleri alınamadı", zap.Uint("user_id", userID), zap.Error(err))
+				return c.Status(fiber.StatusInternalServerError).SendString("Kullanıcı izinleri alınamadı")
+			}
+			c.Locals(permissionsLocalsKey, permissions)
+		}
+
+		if !permissions[permission] {
+			return c.Status(fiber.StatusForbidden).SendString("Bu işlem için yetkiniz yok")
+		}
+
+		return c.Next()
+	}
+}
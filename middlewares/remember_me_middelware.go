@@ -0,0 +1,85 @@
+package middlewares
+
+import (
+	"zatrano/models"
+	"zatrano/services"
+	"zatrano/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// RememberMeMiddleware, oturumu olmayan isteklerde geçerli bir "beni hatırla" çerezi varsa sessizce yeni oturum açar.
+func RememberMeMiddleware(c *fiber.Ctx) error {
+	cookie := c.Cookies(utils.RememberMeCookieName)
+	if cookie == "" {
+		return c.Next()
+	}
+
+	sess, err := utils.SessionStart(c)
+	if err != nil {
+		return c.Next()
+	}
+	if _, err := utils.GetUserIDFromSession(sess); err == nil {
+		return c.Next()
+	}
+
+	ip := c.IP()
+	userAgent := c.Get(fiber.HeaderUserAgent)
+	rememberMeService := services.NewRememberMeService()
+	securityEventService := services.NewSecurityEventService()
+
+	remembered, err := rememberMeService.Consume(cookie, ip, userAgent)
+	if err != nil {
+		switch err {
+		case services.ErrRememberTokenStale:
+			// Aynı çerezle gelen eşzamanlı istek; yeni çerez diğer yanıtla gönderildi.
+		case services.ErrRememberTokenReused:
+			userID := remembered.UserID
+			securityEventService.Record(&models.SecurityEvent{
+				UserID:        &userID,
+				Type:          models.EventRememberTokenReused,
+				FailureReason: models.FailureRememberTokenReused,
+				IP:            ip,
+				UserAgent:     userAgent,
+			})
+			if _, revokeErr := services.NewSessionService().RevokeAll(userID); revokeErr != nil {
+				utils.Log.Error("Çalınmış hatırlama anahtarı sonrası oturumlar sonlandırılamadı", zap.Uint("user_id", userID), zap.Error(revokeErr))
+			}
+			utils.ClearRememberMeCookie(c)
+		default:
+			utils.ClearRememberMeCookie(c)
+		}
+		return c.Next()
+	}
+
+	user := remembered.User
+	if err := utils.EstablishUserSession(sess, user); err != nil {
+		utils.Log.Error("Beni hatırla: Oturum kimliği yenilenemedi", zap.Uint("user_id", user.ID), zap.Error(err))
+		return c.Next()
+	}
+	if !user.TwoFactorEnabled && services.NewTwoFactorService().IsRequiredFor(user.Type) {
+		sess.Set("two_factor_setup_required", true)
+	}
+	if err := sess.Save(); err != nil {
+		utils.Log.Error("Beni hatırla: Oturum kaydedilemedi", zap.Uint("user_id", user.ID), zap.Error(err))
+		return c.Next()
+	}
+
+	if err := services.NewSessionService().Register(user.ID, sess.ID(), ip, userAgent, remembered.Selector); err != nil {
+		_ = sess.Destroy()
+		return c.Next()
+	}
+
+	utils.SetRememberMeCookie(c, remembered.CookieValue, remembered.ExpiresAt)
+	securityEventService.Record(&models.SecurityEvent{
+		UserID:    &user.ID,
+		Account:   user.Account,
+		Type:      models.EventLoginRemembered,
+		Success:   true,
+		IP:        ip,
+		UserAgent: userAgent,
+	})
+
+	return c.Next()
+}
@@ -1,29 +1,18 @@
 package middlewares
 
 import (
-	"zatrano/services"
 	"zatrano/utils"
 
 	"github.com/gofiber/fiber/v2"
 )
 
+// StatusMiddleware, AuthMiddleware'den sonra çalışır ve pasif kullanıcıların isteklerini reddeder.
 func StatusMiddleware(c *fiber.Ctx) error {
-	sess, err := utils.SessionStart(c)
-	if err != nil {
+	user, ok := utils.CurrentUser(c)
+	if !ok {
 		return c.Redirect("/auth/login")
 	}
 
-	userID, err := utils.GetUserIDFromSession(sess)
-	if err != nil {
-		return c.Redirect("/auth/login")
-	}
-
-	authService := services.NewAuthService()
-	user, err := authService.GetUserProfile(userID)
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).SendString("Kullanıcı bulunamadı")
-	}
-
 	if !user.Status {
 		return c.Status(fiber.StatusForbidden).SendString("Kullanıcı aktif değil")
 	}
@@ -0,0 +1,20 @@
+package middlewares
+
+import (
+	"zatrano/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TwoFactorSetupMiddleware(c *fiber.Ctx) error {
+	sess, err := utils.SessionStart(c)
+	if err != nil {
+		return c.Redirect("/auth/login")
+	}
+
+	if required, ok := sess.Get("two_factor_setup_required").(bool); ok && required {
+		return c.Redirect("/auth/2fa/setup")
+	}
+
+	return c.Next()
+}
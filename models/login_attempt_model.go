@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+type LoginIPAttempt struct {
+	ID           uint       `gorm:"primarykey"`
+	IP           string     `gorm:"size:64;not null;uniqueIndex"`
+	FailedCount  int        `gorm:"not null;default:0"`
+	LastFailedAt time.Time  `gorm:"not null"`
+	LockedUntil  *time.Time `gorm:"index"`
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+func (a *LoginIPAttempt) IsLocked() bool {
+	return a.LockedUntil != nil && a.LockedUntil.After(time.Now().UTC())
+}
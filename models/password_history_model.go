@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+type PasswordHistory struct {
+	ID           uint   `gorm:"primarykey"`
+	UserID       uint   `gorm:"not null;index"`
+	PasswordHash string `gorm:"size:255;not null"`
+	CreatedAt    time.Time
+}
@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+type PasswordResetToken struct {
+	ID        uint      `gorm:"primarykey"`
+	UserID    uint      `gorm:"not null;index"`
+	TokenHash string    `gorm:"size:64;not null;uniqueIndex"`
+	ExpiresAt time.Time `gorm:"not null;index"`
+	UsedAt    *time.Time
+	RequestIP string `gorm:"size:64"`
+	CreatedAt time.Time
+}
+
+func (t *PasswordResetToken) IsUsable() bool {
+	return t.UsedAt == nil && t.ExpiresAt.After(time.Now().UTC())
+}
@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// RememberToken, "beni hatırla" çerezinin selector/validator çiftini saklar; validator yalnızca özet olarak tutulur.
+type RememberToken struct {
+	ID                    uint   `gorm:"primarykey"`
+	UserID                uint   `gorm:"not null;index"`
+	Selector              string `gorm:"size:32;not null;uniqueIndex"`
+	ValidatorHash         string `gorm:"size:64;not null"`
+	PreviousValidatorHash string `gorm:"size:64"`
+	RotatedAt             *time.Time
+	ExpiresAt             time.Time `gorm:"not null;index"`
+	LastUsedAt            *time.Time
+	IP                    string `gorm:"size:64"`
+	UserAgent             string `gorm:"size:255"`
+	CreatedAt             time.Time
+}
+
+func (t *RememberToken) IsExpired() bool {
+	return !t.ExpiresAt.After(time.Now().UTC())
+}
@@ -0,0 +1,132 @@
+package models
+
+import "time"
+
+const (
+	PermDashboardAccess  = "dashboard.access"
+	PermPanelAccess      = "panel.access"
+	PermUsersView        = "users.view"
+	PermUsersCreate      = "users.create"
+	PermUsersUpdate      = "users.update"
+	PermUsersDelete      = "users.delete"
+	PermUsersSecurity    = "users.security"
+	PermUsersAllTeams    = "users.all_teams"
+	PermTeamsManage      = "teams.manage"
+	PermSecurityPolicies = "security.policies"
+	PermSecurityEvents   = "security.events"
+	PermRolesManage      = "roles.manage"
+)
+
+const (
+	RoleSlugSystem    = "system"
+	RoleSlugPanel     = "panel"
+	RoleSlugTeamAdmin = "team_admin"
+)
+
+type Permission struct {
+	ID        uint   `gorm:"primarykey"`
+	Key       string `gorm:"size:100;not null;uniqueIndex"`
+	Name      string `gorm:"size:150;not null"`
+	CreatedAt time.Time
+}
+
+type Role struct {
+	ID          uint         `gorm:"primarykey"`
+	Name        string       `gorm:"size:100;not null;uniqueIndex"`
+	Slug        string       `gorm:"size:100;not null;uniqueIndex"`
+	Description string       `gorm:"size:255"`
+	BuiltIn     bool         `gorm:"not null;default:false"`
+	Permissions []Permission `gorm:"many2many:role_permissions;constraint:OnDelete:CASCADE"`
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (r *Role) HasPermission(key string) bool {
+	for _, p := range r.Permissions {
+		if p.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// HasSystemRole, rollerden birinin yerleşik sistem yöneticisi rolü olup olmadığını döner.
+func HasSystemRole(roles []Role) bool {
+	for _, role := range roles {
+		if role.BuiltIn && role.Slug == RoleSlugSystem {
+			return true
+		}
+	}
+	return false
+}
+
+// PermissionDefinitions, uygulamanın tanıdığı tüm izinleri ekranda gösterilecek adlarıyla döner.
+func PermissionDefinitions() []Permission {
+	return []Permission{
+		{Key: PermDashboardAccess, Name: "Yönetim paneline erişim"},
+		{Key: PermPanelAccess, Name: "Ajan paneline erişim"},
+		{Key: PermUsersView, Name: "Kullanıcıları görüntüleme"},
+		{Key: PermUsersCreate, Name: "Kullanıcı oluşturma"},
+		{Key: PermUsersUpdate, Name: "Kullanıcı düzenleme"},
+		{Key: PermUsersDelete, Name: "Kullanıcı silme"},
+		{Key: PermUsersSecurity, Name: "Kullanıcı güvenlik işlemleri (kilit, 2FA, oturumlar)"},
+		{Key: PermUsersAllTeams, Name: "Tüm takımların kullanıcılarını yönetme (yoksa yalnızca kendi takımı)"},
+		{Key: PermTeamsManage, Name: "Takımları yönetme"},
+		{Key: PermSecurityPolicies, Name: "Güvenlik politikalarını yönetme"},
+		{Key: PermSecurityEvents, Name: "Giriş geçmişini görüntüleme"},
+		{Key: PermRolesManage, Name: "Rolleri yönetme"},
+	}
+}
+
+// BuiltInRole, kurulumda oluşturulan ve silinemeyen rollerin tanımıdır.
+type BuiltInRole struct {
+	Slug        string
+	Name        string
+	Description string
+	UserType    UserType
+	Permissions []string
+}
+
+func BuiltInRoles() []BuiltInRole {
+	allPermissions := make([]string, 0, len(PermissionDefinitions()))
+	for _, p := range PermissionDefinitions() {
+		allPermissions = append(allPermissions, p.Key)
+	}
+
+	return []BuiltInRole{
+		{
+			Slug:        RoleSlugSystem,
+			Name:        "Sistem Yöneticisi",
+			Description: "Tüm izinlere sahip yerleşik rol.",
+			UserType:    System,
+			Permissions: allPermissions,
+		},
+		{
+			Slug:        RoleSlugPanel,
+			Name:        "Ajan",
+			Description: "Yalnızca ajan paneline erişebilen yerleşik rol.",
+			UserType:    Panel,
+			Permissions: []string{PermPanelAccess},
+		},
+		{
+			Slug:        RoleSlugTeamAdmin,
+			Name:        "Takım Yöneticisi",
+			Description: "Yalnızca kendi takımındaki kullanıcıları görebilen ve yönetebilen yerleşik rol.",
+			Permissions: []string{
+				PermDashboardAccess, PermPanelAccess,
+				PermUsersView, PermUsersCreate, PermUsersUpdate, PermUsersDelete, PermUsersSecurity,
+			},
+		},
+	}
+}
+
+// DefaultRoleSlugFor, kullanıcı tipine karşılık gelen yerleşik rolün kısa adını döner.
+func DefaultRoleSlugFor(userType UserType) string {
+	for _, role := range BuiltInRoles() {
+		if role.UserType != "" && role.UserType == userType {
+			return role.Slug
+		}
+	}
+	return RoleSlugPanel
+}
@@ -0,0 +1,93 @@
+package models
+
+import "time"
+
+type SecurityEventType string
+
+const (
+	EventLoginSucceeded         SecurityEventType = "login_succeeded"
+	EventLoginFailed            SecurityEventType = "login_failed"
+	EventLogout                 SecurityEventType = "logout"
+	EventPasswordChanged        SecurityEventType = "password_changed"
+	EventPasswordReset          SecurityEventType = "password_reset"
+	EventPasswordChangedByAdmin SecurityEventType = "password_changed_by_admin"
+	EventLoginRemembered        SecurityEventType = "login_remembered"
+	EventRememberTokenReused    SecurityEventType = "remember_token_reused"
+	EventInvitationAccepted     SecurityEventType = "invitation_accepted"
+)
+
+// Giriş denemelerinin başarısızlık nedenleri.
+const (
+	FailureInvalidCredentials  = "invalid_credentials"
+	FailureAccountLocked       = "account_locked"
+	FailureIPLocked            = "ip_locked"
+	FailureUserInactive        = "user_inactive"
+	FailureInvalidTwoFactor    = "invalid_two_factor_code"
+	FailureInternalError       = "internal_error"
+	FailureRememberTokenReused = "remember_token_reused"
+	FailureInvitationPending   = "invitation_pending"
+)
+
+var securityEventTypeLabels = map[SecurityEventType]string{
+	EventLoginSucceeded:         "Giriş",
+	EventLoginFailed:            "Başarısız giriş",
+	EventLogout:                 "Çıkış",
+	EventPasswordChanged:        "Şifre değişikliği",
+	EventPasswordReset:          "Şifre sıfırlama",
+	EventPasswordChangedByAdmin: "Şifre değişikliği (yönetici)",
+	EventLoginRemembered:        "Giriş (beni hatırla)",
+	EventRememberTokenReused:    "Hatırlama anahtarı yeniden kullanıldı",
+	EventInvitationAccepted:     "Davet kabul edildi",
+}
+
+var failureReasonLabels = map[string]string{
+	FailureInvalidCredentials:  "Hatalı hesap adı veya şifre",
+	FailureAccountLocked:       "Hesap kilitli",
+	FailureIPLocked:            "IP adresi geçici olarak engellendi",
+	FailureUserInactive:        "Hesap aktif değil",
+	FailureInvalidTwoFactor:    "Hatalı doğrulama kodu",
+	FailureInternalError:       "Sunucu hatası",
+	FailureRememberTokenReused: "Anahtar çalınmış olabilir, tüm cihazlar çıkış yaptı",
+	FailureInvitationPending:   "Davet henüz kabul edilmedi",
+}
+
+// SecurityEventTypes, filtre formlarında gösterilecek olay tiplerini sabit sırayla döner.
+func SecurityEventTypes() []SecurityEventType {
+	return []SecurityEventType{
+		EventLoginSucceeded,
+		EventLoginFailed,
+		EventLogout,
+		EventPasswordChanged,
+		EventPasswordReset,
+		EventPasswordChangedByAdmin,
+		EventLoginRemembered,
+		EventRememberTokenReused,
+		EventInvitationAccepted,
+	}
+}
+
+func (t SecurityEventType) Label() string {
+	if label, ok := securityEventTypeLabels[t]; ok {
+		return label
+	}
+	return string(t)
+}
+
+type SecurityEvent struct {
+	ID            uint              `gorm:"primarykey"`
+	UserID        *uint             `gorm:"index"`
+	Account       string            `gorm:"size:100;not null;index"`
+	Type          SecurityEventType `gorm:"size:32;not null;index"`
+	Success       bool              `gorm:"not null;index"`
+	FailureReason string            `gorm:"size:64"`
+	IP            string            `gorm:"size:64;index"`
+	UserAgent     string            `gorm:"size:255"`
+	CreatedAt     time.Time         `gorm:"index"`
+}
+
+func (e *SecurityEvent) FailureReasonLabel() string {
+	if label, ok := failureReasonLabels[e.FailureReason]; ok {
+		return label
+	}
+	return e.FailureReason
+}
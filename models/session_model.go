@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+type Session struct {
+	ID        string     `gorm:"primarykey;size:128"`
+	Data      []byte     `gorm:"type:bytea;not null"`
+	ExpiresAt *time.Time `gorm:"index"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+type Team struct {
+	ID          uint   `gorm:"primarykey"`
+	Name        string `gorm:"size:100;not null;uniqueIndex"`
+	Description string `gorm:"size:255"`
+	Status      bool   `gorm:"not null;default:true;index"`
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
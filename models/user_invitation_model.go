@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+// UserInvitation, yöneticinin davet ettiği kullanıcının şifresini belirlemesi için gönderilen tek kullanımlık
+// bağlantıdır. Token yalnızca özet olarak saklanır.
+type UserInvitation struct {
+	ID          uint      `gorm:"primarykey"`
+	UserID      uint      `gorm:"not null;index"`
+	TokenHash   string    `gorm:"size:64;not null;uniqueIndex"`
+	ExpiresAt   time.Time `gorm:"not null;index"`
+	UsedAt      *time.Time
+	InvitedByID *uint
+	CreatedAt   time.Time
+}
+
+func (i *UserInvitation) IsUsable() bool {
+	return i.UsedAt == nil && i.ExpiresAt.After(time.Now().UTC())
+}
+
+type InvitationStatus string
+
+const (
+	InvitationNone     InvitationStatus = ""
+	InvitationPending  InvitationStatus = "pending"
+	InvitationExpired  InvitationStatus = "expired"
+	InvitationRevoked  InvitationStatus = "revoked"
+	InvitationAccepted InvitationStatus = "accepted"
+)
+
+// InvitationStatus, kullanıcının davet durumunu döner; davetle oluşturulmamış kullanıcılarda boştur.
+func (u *User) InvitationStatus() InvitationStatus {
+	switch {
+	case u.InvitedAt == nil:
+		return InvitationNone
+	case !u.Pending:
+		return InvitationAccepted
+	case u.InvitationExpiresAt == nil:
+		return InvitationRevoked
+	case u.InvitationExpiresAt.Before(time.Now().UTC()):
+		return InvitationExpired
+	}
+	return InvitationPending
+}
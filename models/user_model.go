@@ -1,8 +1,13 @@
 package models
 
 import (
+	"time"
+
+	"zatrano/passwordpolicy"
+
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"gorm.io/gorm/schema"
 )
 
@@ -38,21 +43,57 @@ func (UserType) GormDBDataType(db *gorm.DB, field *schema.Field) string {
 type User struct {
 	gorm.Model
 	Name     string   `gorm:"size:100;not null;index"`
-	Account  string   `gorm:"size:100;unique;not null"`
+	Account  string   `gorm:"size:100;not null;uniqueIndex:idx_users_account_active,where:deleted_at IS NULL"`
 	Password string   `gorm:"size:255;not null"`
 	Status   bool     `gorm:"default:true;index"`
 	Type     UserType `gorm:"type:user_type;not null;default:'panel';index"`
+	TeamID   *uint    `gorm:"index"`
+	Team     *Team    `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+
+	Email string `gorm:"size:255;index"`
+	Phone string `gorm:"size:32"`
+	// AvatarKey, profil resmi küçük resimlerinin depodaki ortak önekidir; boşsa profil resmi yoktur.
+	AvatarKey string `gorm:"size:255"`
+
+	FailedLoginAttempts int `gorm:"not null;default:0"`
+	LastFailedLoginAt   *time.Time
+	LockedUntil         *time.Time `gorm:"index"`
+
+	TwoFactorEnabled   bool   `gorm:"not null;default:false"`
+	TwoFactorSecret    string `gorm:"size:64"`
+	TwoFactorLastStep  int64  `gorm:"not null;default:0"`
+	TwoFactorEnabledAt *time.Time
+
+	// CredentialsChangedAt, şifrenin son değiştirildiği zamandır ve yalnızca şifre yaşı politikasında (MaxAgeDays)
+	// kullanılır; oturumların geçersiz kılınması SessionVersion ile yapılır.
+	CredentialsChangedAt *time.Time
+	SessionVersion       int  `gorm:"not null;default:1"`
+	MustChangePassword   bool `gorm:"not null;default:false"`
+
+	// Pending, davetle oluşturulup şifresini henüz belirlememiş kullanıcıları işaretler; bu kullanıcılar giriş yapamaz.
+	Pending             bool `gorm:"not null;default:false;index"`
+	InvitedAt           *time.Time
+	InvitationExpiresAt *time.Time
+
+	// Protected, kurulumda oluşturulan sistem kullanıcısı gibi kullanıcı listesinde gösterilmeyen ve
+	// başka kullanıcılar tarafından değiştirilemeyen ya da silinemeyen hesapları işaretler.
+	Protected bool `gorm:"not null;default:false"`
+
+	Roles []Role `gorm:"many2many:user_roles;constraint:OnDelete:CASCADE"`
 }
 
 func (u *User) BeforeCreate(tx *gorm.DB) (err error) {
 	if u.Password == "" {
 		return ErrPasswordCannotBeEmpty
 	}
-	hashed, bcryptErr := bcrypt.GenerateFromPassword([]byte(u.Password), bcrypt.DefaultCost)
-	if bcryptErr != nil {
-		return bcryptErr
+	// SetPassword ile önceden hashlenmiş parolalar tekrar hashlenmez.
+	if _, costErr := bcrypt.Cost([]byte(u.Password)); costErr != nil {
+		hashed, bcryptErr := bcrypt.GenerateFromPassword([]byte(u.Password), bcrypt.DefaultCost)
+		if bcryptErr != nil {
+			return bcryptErr
+		}
+		u.Password = string(hashed)
 	}
-	u.Password = string(hashed)
 
 	validTypes := map[UserType]bool{System: true, Panel: true}
 	if _, typeIsValid := validTypes[u.Type]; !typeIsValid {
@@ -96,10 +137,14 @@ func (u *User) BeforeUpdate(tx *gorm.DB) (err error) {
 	return nil
 }
 
+// SetPassword, parolayı kullanıcının tipine ait şifre politikasına göre doğrular ve hashler.
 func (u *User) SetPassword(password string) error {
 	if password == "" {
 		return ErrPasswordCannotBeEmpty
 	}
+	if err := passwordpolicy.For(string(u.Type)).Validate(password, u.Account); err != nil {
+		return err
+	}
 	hashed, bcryptErr := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 	if bcryptErr != nil {
 		return bcryptErr
@@ -110,3 +155,52 @@ func (u *User) SetPassword(password string) error {
 func (u *User) CheckPassword(password string) error {
 	return bcrypt.CompareHashAndPassword([]byte(u.Password), []byte(password))
 }
+
+// NextSessionVersion, güncelleme verisinde kullanıldığında kullanıcının mevcut tüm oturumlarını geçersiz kılar.
+func NextSessionVersion() clause.Expr {
+	return gorm.Expr("session_version + 1")
+}
+
+// AvatarURL, profil resminin istenen boyuttaki ("sm" veya "lg") indirme adresini döner; resim yoksa boş döner.
+// Anahtar her yüklemede değiştiği için adres tarayıcı önbelleğini kendiliğinden atlar.
+func (u *User) AvatarURL(size string) string {
+	if u.AvatarKey == "" {
+		return ""
+	}
+	return "/files/" + u.AvatarKey + "_" + size + ".png"
+}
+
+// InTeam, kullanıcının verilen takımın üyesi olup olmadığını döner.
+func (u *User) InTeam(teamID uint) bool {
+	return u.TeamID != nil && *u.TeamID == teamID
+}
+
+func (u *User) HasRole(roleID uint) bool {
+	for _, role := range u.Roles {
+		if role.ID == roleID {
+			return true
+		}
+	}
+	return false
+}
+
+// PasswordChangeRequired, kullanıcı şifre değiştirmeye zorlanmışsa veya şifresi tipine ait
+// politikadaki azami süreyi aşmışsa true döner.
+func (u *User) PasswordChangeRequired() bool {
+	if u.MustChangePassword {
+		return true
+	}
+	maxAgeDays := passwordpolicy.For(string(u.Type)).MaxAgeDays
+	if maxAgeDays <= 0 {
+		return false
+	}
+	changedAt := u.CreatedAt
+	if u.CredentialsChangedAt != nil {
+		changedAt = *u.CredentialsChangedAt
+	}
+	return time.Now().UTC().After(changedAt.AddDate(0, 0, maxAgeDays))
+}
+
+func (u *User) IsLocked() bool {
+	return u.LockedUntil != nil && u.LockedUntil.After(time.Now().UTC())
+}
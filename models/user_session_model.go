@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+type UserSession struct {
+	ID          uint   `gorm:"primarykey"`
+	UserID      uint   `gorm:"not null;index"`
+	SessionHash string `gorm:"size:64;not null;uniqueIndex"`
+	IP          string `gorm:"size:64"`
+	UserAgent   string `gorm:"size:255"`
+	// RememberSelector, oturum "beni hatırla" ile açıldıysa ilgili tokenın selector değeridir.
+	RememberSelector string    `gorm:"size:32;index"`
+	LastActivityAt   time.Time `gorm:"not null;index"`
+	CreatedAt        time.Time
+}
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"time"
+
+	"zatrano/passwordpolicy"
+)
+
+type UserTypePolicy struct {
+	ID               uint     `gorm:"primarykey"`
+	Type             UserType `gorm:"type:user_type;not null;uniqueIndex"`
+	RequireTwoFactor bool     `gorm:"not null;default:false"`
+
+	PasswordMinLength       int  `gorm:"not null;default:8"`
+	PasswordRequireUpper    bool `gorm:"not null;default:true"`
+	PasswordRequireLower    bool `gorm:"not null;default:true"`
+	PasswordRequireDigit    bool `gorm:"not null;default:true"`
+	PasswordRequireSymbol   bool `gorm:"not null;default:false"`
+	PasswordDisallowAccount bool `gorm:"not null;default:true"`
+	PasswordDisallowCommon  bool `gorm:"not null;default:true"`
+	PasswordHistoryCount    int  `gorm:"not null;default:5"`
+	PasswordMaxAgeDays      int  `gorm:"not null;default:0"`
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func AllUserTypes() []UserType {
+	return []UserType{System, Panel}
+}
+
+// DefaultUserTypePolicy, veritabanında kaydı olmayan tipler için sütun varsayılanlarıyla aynı politikayı döner.
+func DefaultUserTypePolicy(userType UserType) UserTypePolicy {
+	policy := UserTypePolicy{Type: userType}
+	policy.SetPasswordPolicy(passwordpolicy.Default())
+	return policy
+}
+
+func (p *UserTypePolicy) PasswordPolicy() passwordpolicy.Policy {
+	return passwordpolicy.Policy{
+		MinLength:           p.PasswordMinLength,
+		RequireUpper:        p.PasswordRequireUpper,
+		RequireLower:        p.PasswordRequireLower,
+		RequireDigit:        p.PasswordRequireDigit,
+		RequireSymbol:       p.PasswordRequireSymbol,
+		DisallowAccountName: p.PasswordDisallowAccount,
+		DisallowCommon:      p.PasswordDisallowCommon,
+		HistoryCount:        p.PasswordHistoryCount,
+		MaxAgeDays:          p.PasswordMaxAgeDays,
+	}
+}
+
+func (p *UserTypePolicy) SetPasswordPolicy(policy passwordpolicy.Policy) {
+	p.PasswordMinLength = policy.MinLength
+	p.PasswordRequireUpper = policy.RequireUpper
+	p.PasswordRequireLower = policy.RequireLower
+	p.PasswordRequireDigit = policy.RequireDigit
+	p.PasswordRequireSymbol = policy.RequireSymbol
+	p.PasswordDisallowAccount = policy.DisallowAccountName
+	p.PasswordDisallowCommon = policy.DisallowCommon
+	p.PasswordHistoryCount = policy.HistoryCount
+	p.PasswordMaxAgeDays = policy.MaxAgeDays
+}
@@ -0,0 +1,31 @@
+package passwordpolicy
+
+import (
+	_ "embed"
+	"strings"
+	"sync"
+)
+
+//go:embed common_passwords.txt
+var commonPasswordsFile string
+
+var (
+	commonOnce      sync.Once
+	commonPasswords map[string]struct{}
+)
+
+// IsCommon, parolanın yerel yaygın parola listesinde olup olmadığını büyük/küçük harf duyarsız kontrol eder.
+func IsCommon(password string) bool {
+	commonOnce.Do(func() {
+		commonPasswords = make(map[string]struct{})
+		for _, line := range strings.Split(commonPasswordsFile, "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			commonPasswords[strings.ToLower(line)] = struct{}{}
+		}
+	})
+	_, found := commonPasswords[strings.ToLower(password)]
+	return found
+}
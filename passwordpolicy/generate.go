@@ -0,0 +1,79 @@
+package passwordpolicy
+
+import (
+	"crypto/rand"
+	"math/big"
+)
+
+const (
+	generatedMinLength = 16
+	generateAttempts   = 20
+
+	lowerChars  = "abcdefghijkmnopqrstuvwxyz"
+	upperChars  = "ABCDEFGHJKLMNPQRSTUVWXYZ"
+	digitChars  = "23456789"
+	symbolChars = "!@#$%&*?-+="
+)
+
+// Generate, politikanın tüm kurallarını sağlayan rastgele bir şifre üretir. Karışıklığa yol açan
+// karakterler (0/O, 1/l/I) kullanılmaz.
+func (p Policy) Generate(account string) (string, error) {
+	length := generatedMinLength
+	if p.MinLength > length {
+		length = p.MinLength
+	}
+	if length > maxPasswordBytes {
+		length = maxPasswordBytes
+	}
+
+	groups := []string{lowerChars, upperChars, digitChars, symbolChars}
+	all := lowerChars + upperChars + digitChars + symbolChars
+
+	for attempt := 0; attempt < generateAttempts; attempt++ {
+		chars := make([]byte, 0, length)
+		// Her karakter grubundan en az bir tane bulunması büyük/küçük harf, rakam ve sembol kurallarını garanti eder.
+		for _, group := range groups {
+			c, err := randomChar(group)
+			if err != nil {
+				return "", err
+			}
+			chars = append(chars, c)
+		}
+		for len(chars) < length {
+			c, err := randomChar(all)
+			if err != nil {
+				return "", err
+			}
+			chars = append(chars, c)
+		}
+		if err := shuffle(chars); err != nil {
+			return "", err
+		}
+
+		password := string(chars)
+		if len(p.Check(password, account)) == 0 {
+			return password, nil
+		}
+	}
+	return "", NewError([]Violation{{Field: FieldPassword, Message: "Politikaya uygun bir şifre üretilemedi."}})
+}
+
+func randomChar(set string) (byte, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(int64(len(set))))
+	if err != nil {
+		return 0, err
+	}
+	return set[n.Int64()], nil
+}
+
+func shuffle(chars []byte) error {
+	for i := len(chars) - 1; i > 0; i-- {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			return err
+		}
+		j := n.Int64()
+		chars[i], chars[j] = chars[j], chars[i]
+	}
+	return nil
+}
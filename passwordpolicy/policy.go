@@ -0,0 +1,195 @@
+package passwordpolicy
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// FieldPassword, ihlallerin bağlı olduğu form alanının adıdır.
+const FieldPassword = "password"
+
+// maxPasswordBytes, bcrypt'in dikkate aldığı en uzun parola uzunluğudur.
+const maxPasswordBytes = 72
+
+type Policy struct {
+	MinLength           int
+	RequireUpper        bool
+	RequireLower        bool
+	RequireDigit        bool
+	RequireSymbol       bool
+	DisallowAccountName bool
+	DisallowCommon      bool
+	HistoryCount        int
+	// MaxAgeDays, şifrenin kaç gün sonra zorunlu olarak değiştirileceğidir; 0 süresiz demektir.
+	MaxAgeDays int
+}
+
+func Default() Policy {
+	return Policy{
+		MinLength:           8,
+		RequireUpper:        true,
+		RequireLower:        true,
+		RequireDigit:        true,
+		RequireSymbol:       false,
+		DisallowAccountName: true,
+		DisallowCommon:      true,
+		HistoryCount:        5,
+		MaxAgeDays:          0,
+	}
+}
+
+type Violation struct {
+	Field   string
+	Message string
+}
+
+// PolicyError, bir parolanın ihlal ettiği tüm kuralları alan bazında taşır.
+type PolicyError struct {
+	Violations []Violation
+}
+
+func (e *PolicyError) Error() string {
+	return strings.Join(e.Messages(), " ")
+}
+
+func (e *PolicyError) Messages() []string {
+	messages := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		messages = append(messages, v.Message)
+	}
+	return messages
+}
+
+func (e *PolicyError) FieldMessages() map[string][]string {
+	fields := make(map[string][]string)
+	for _, v := range e.Violations {
+		fields[v.Field] = append(fields[v.Field], v.Message)
+	}
+	return fields
+}
+
+// NewError, ihlal yoksa nil, varsa *PolicyError döner.
+func NewError(violations []Violation) error {
+	if len(violations) == 0 {
+		return nil
+	}
+	return &PolicyError{Violations: violations}
+}
+
+// HistoryViolation, parolanın son kullanılanlardan biri olduğunu bildiren ihlali üretir.
+func (p Policy) HistoryViolation() Violation {
+	return Violation{Field: FieldPassword, Message: fmt.Sprintf("Şifre son %d şifrenizden biriyle aynı olamaz.", p.HistoryCount)}
+}
+
+// Check, geçmiş dışındaki tüm kuralları uygular; geçmiş kontrolü veritabanı gerektirdiğinden servis katmanındadır.
+func (p Policy) Check(password, account string) []Violation {
+	var violations []Violation
+	add := func(message string) {
+		violations = append(violations, Violation{Field: FieldPassword, Message: message})
+	}
+
+	if password == "" {
+		add("Şifre boş olamaz.")
+		return violations
+	}
+
+	if p.MinLength > 0 && utf8.RuneCountInString(password) < p.MinLength {
+		add(fmt.Sprintf("Şifre en az %d karakter olmalıdır.", p.MinLength))
+	}
+	if len(password) > maxPasswordBytes {
+		add(fmt.Sprintf("Şifre en fazla %d bayt uzunluğunda olabilir.", maxPasswordBytes))
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case !unicode.IsSpace(r) && !unicode.IsLetter(r):
+			hasSymbol = true
+		}
+	}
+	if p.RequireUpper && !hasUpper {
+		add("Şifre en az bir büyük harf içermelidir.")
+	}
+	if p.RequireLower && !hasLower {
+		add("Şifre en az bir küçük harf içermelidir.")
+	}
+	if p.RequireDigit && !hasDigit {
+		add("Şifre en az bir rakam içermelidir.")
+	}
+	if p.RequireSymbol && !hasSymbol {
+		add("Şifre en az bir özel karakter (ör. !, @, #) içermelidir.")
+	}
+
+	if p.DisallowAccountName && containsAccountName(password, account) {
+		add("Şifre hesap adınızı içeremez.")
+	}
+	if p.DisallowCommon && IsCommon(password) {
+		add("Bu şifre çok yaygın kullanılıyor, lütfen daha güçlü bir şifre seçin.")
+	}
+
+	return violations
+}
+
+func (p Policy) Validate(password, account string) error {
+	return NewError(p.Check(password, account))
+}
+
+// Requirements, formlarda gösterilecek kural özetini döner.
+func (p Policy) Requirements() []string {
+	var items []string
+	if p.MinLength > 0 {
+		items = append(items, fmt.Sprintf("En az %d karakter", p.MinLength))
+	}
+	if p.RequireUpper {
+		items = append(items, "En az bir büyük harf")
+	}
+	if p.RequireLower {
+		items = append(items, "En az bir küçük harf")
+	}
+	if p.RequireDigit {
+		items = append(items, "En az bir rakam")
+	}
+	if p.RequireSymbol {
+		items = append(items, "En az bir özel karakter")
+	}
+	if p.DisallowAccountName {
+		items = append(items, "Hesap adını içermemeli")
+	}
+	if p.DisallowCommon {
+		items = append(items, "Yaygın kullanılan bir şifre olmamalı")
+	}
+	if p.HistoryCount > 0 {
+		items = append(items, fmt.Sprintf("Son %d şifreden farklı olmalı", p.HistoryCount))
+	}
+	if p.MaxAgeDays > 0 {
+		items = append(items, fmt.Sprintf("%d günde bir değiştirilmeli", p.MaxAgeDays))
+	}
+	return items
+}
+
+func containsAccountName(password, account string) bool {
+	account = strings.ToLower(strings.TrimSpace(account))
+	if account == "" {
+		return false
+	}
+	lowered := strings.ToLower(password)
+
+	candidates := []string{account}
+	if local, _, found := strings.Cut(account, "@"); found {
+		candidates = append(candidates, local)
+	}
+	for _, candidate := range candidates {
+		if utf8.RuneCountInString(candidate) >= 3 && strings.Contains(lowered, candidate) {
+			return true
+		}
+	}
+	return false
+}
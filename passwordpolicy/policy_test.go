@@ -0,0 +1,123 @@
+package passwordpolicy
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestPolicyCheck(t *testing.T) {
+	strict := Policy{
+		MinLength:           10,
+		RequireUpper:        true,
+		RequireLower:        true,
+		RequireDigit:        true,
+		RequireSymbol:       true,
+		DisallowAccountName: true,
+		DisallowCommon:      true,
+	}
+
+	tests := []struct {
+		name     string
+		policy   Policy
+		password string
+		account  string
+		want     []string
+	}{
+		{"boş şifre", strict, "", "ali", []string{"Şifre boş olamaz."}},
+		{"tüm kurallara uyan", strict, "Gökyüzü-42-Mavi", "ali", nil},
+		{"kısa", strict, "Ab1!xyz", "ali", []string{"Şifre en az 10 karakter olmalıdır."}},
+		{"uzunluk rune ile sayılır", strict, "Çğüşıö1!Aa", "ali", nil},
+		{"bcrypt sınırını aşan", Policy{}, strings.Repeat("a", maxPasswordBytes+1), "", []string{"Şifre en fazla 72 bayt uzunluğunda olabilir."}},
+		{"büyük harf yok", strict, "gokyuzu-42-mavi", "ali", []string{"Şifre en az bir büyük harf içermelidir."}},
+		{"küçük harf yok", strict, "GOKYUZU-42-MAVI", "ali", []string{"Şifre en az bir küçük harf içermelidir."}},
+		{"rakam yok", strict, "Gokyuzu-Mavi!", "ali", []string{"Şifre en az bir rakam içermelidir."}},
+		{"sembol yok", strict, "Gokyuzu42Mavi", "ali", []string{"Şifre en az bir özel karakter (ör. !, @, #) içermelidir."}},
+		{"boşluk sembol sayılmaz", strict, "Gokyuzu 42 Mavi", "ali", []string{"Şifre en az bir özel karakter (ör. !, @, #) içermelidir."}},
+		{"sınıf kuralları kapalı", Policy{MinLength: 4}, "aaaa", "", nil},
+		{"hesap adını içeren", strict, "Xx-Veli-2024!", "veli", []string{"Şifre hesap adınızı içeremez."}},
+		{"hesap adı büyük/küçük harf duyarsız", strict, "Xx-VELI-2024!", "Veli", []string{"Şifre hesap adınızı içeremez."}},
+		{"e-posta hesabının yerel kısmı", strict, "Xx-ayse-2024!", "ayse@example.com", []string{"Şifre hesap adınızı içeremez."}},
+		{"3 karakterden kısa hesap adı yok sayılır", strict, "Xx-ab-20245!", "ab", nil},
+		{"hesap adı kuralı kapalı", Policy{DisallowAccountName: false}, "veli2024", "veli", nil},
+		{"yaygın şifre", Policy{DisallowCommon: true}, "qwerty123", "", []string{"Bu şifre çok yaygın kullanılıyor, lütfen daha güçlü bir şifre seçin."}},
+		{"yaygın şifre büyük/küçük harf duyarsız", Policy{DisallowCommon: true}, "PASSWORD123", "", []string{"Bu şifre çok yaygın kullanılıyor, lütfen daha güçlü bir şifre seçin."}},
+		{"yaygın şifre kuralı kapalı", Policy{}, "qwerty123", "", nil},
+		{"birden çok ihlal", strict, "abc", "ali", []string{
+			"Şifre en az 10 karakter olmalıdır.",
+			"Şifre en az bir büyük harf içermelidir.",
+			"Şifre en az bir rakam içermelidir.",
+			"Şifre en az bir özel karakter (ör. !, @, #) içermelidir.",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, v := range tt.policy.Check(tt.password, tt.account) {
+				if v.Field != FieldPassword {
+					t.Errorf("ihlal alanı %q, beklenen %q", v.Field, FieldPassword)
+				}
+				got = append(got, v.Message)
+			}
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("Check(%q, %q)\n  alınan:   %q\n  beklenen: %q", tt.password, tt.account, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPolicyValidate(t *testing.T) {
+	if err := Default().Validate("Gokyuzu42", "ali"); err != nil {
+		t.Fatalf("uygun şifre reddedildi: %v", err)
+	}
+
+	err := Default().Validate("kisa", "ali")
+	var policyErr *PolicyError
+	if !errors.As(err, &policyErr) {
+		t.Fatalf("*PolicyError bekleniyordu, alınan %T", err)
+	}
+	if len(policyErr.FieldMessages()[FieldPassword]) != len(policyErr.Violations) {
+		t.Errorf("tüm ihlaller şifre alanına bağlanmalı: %v", policyErr.FieldMessages())
+	}
+}
+
+func TestIsCommon(t *testing.T) {
+	tests := map[string]bool{
+		"123456":     true,
+		"Password1!": true,
+		"password1!": true,
+		"":           false,
+		"# Yaygın":   false,
+		"Gokyuzu42":  false,
+	}
+	for password, want := range tests {
+		if got := IsCommon(password); got != want {
+			t.Errorf("IsCommon(%q) = %v, beklenen %v", password, got, want)
+		}
+	}
+}
+
+func TestGenerateSatisfiesPolicy(t *testing.T) {
+	policy := Policy{
+		MinLength:           20,
+		RequireUpper:        true,
+		RequireLower:        true,
+		RequireDigit:        true,
+		RequireSymbol:       true,
+		DisallowAccountName: true,
+		DisallowCommon:      true,
+	}
+	for i := 0; i < 20; i++ {
+		password, err := policy.Generate("ali")
+		if err != nil {
+			t.Fatalf("Generate: %v", err)
+		}
+		if len(password) != policy.MinLength {
+			t.Errorf("uzunluk %d, beklenen %d", len(password), policy.MinLength)
+		}
+		if violations := policy.Check(password, "ali"); len(violations) > 0 {
+			t.Errorf("üretilen şifre %q politikaya uymuyor: %v", password, violations)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package passwordpolicy
+
+import "sync"
+
+// Resolver, kullanıcı tipine göre geçerli politikayı döner.
+type Resolver func(userType string) Policy
+
+var (
+	resolverMu sync.RWMutex
+	resolver   Resolver
+)
+
+// SetResolver, politikaların nereden okunacağını belirler. Ayarlanmazsa Default kullanılır.
+func SetResolver(r Resolver) {
+	resolverMu.Lock()
+	defer resolverMu.Unlock()
+	resolver = r
+}
+
+func For(userType string) Policy {
+	resolverMu.RLock()
+	r := resolver
+	resolverMu.RUnlock()
+	if r == nil {
+		return Default()
+	}
+	return r(userType)
+}
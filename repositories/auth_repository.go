@@ -1,6 +1,8 @@
 package repositories
 
 import (
+	"time"
+
 	"zatrano/configs"
 	"zatrano/models"
 
@@ -11,6 +13,10 @@ type IAuthRepository interface {
 	FindUserByAccount(account string) (*models.User, error)
 	FindUserByID(id uint) (*models.User, error)
 	UpdateUser(user *models.User) error
+	UpdateUserFields(id uint, data map[string]interface{}) error
+	// RegisterLoginFailure, hesabın başarısız giriş sayacını tek sorguda artırıp yeni değerini döner; son
+	// deneme windowStart'tan önceyse sayaç 1'den başlar.
+	RegisterLoginFailure(id uint, now, windowStart time.Time) (int, error)
 }
 
 type AuthRepository struct {
@@ -30,17 +36,40 @@ func (r *AuthRepository) FindUserByAccount(account string) (*models.User, error)
 	return &user, nil
 }
 
+// FindUserByID, her kimlik doğrulamalı istekte çağrıldığı için kısa süreli kullanıcı önbelleğinden okur.
 func (r *AuthRepository) FindUserByID(id uint) (*models.User, error) {
+	if user, ok := cachedUser(id, false); ok {
+		return user, nil
+	}
+
 	var user models.User
 	err := r.db.First(&user, id).Error
 	if err != nil {
 		return nil, err
 	}
+	storeCachedUser(&user, false)
 	return &user, nil
 }
 
 func (r *AuthRepository) UpdateUser(user *models.User) error {
+	defer InvalidateCachedUsers(user.ID)
 	return r.db.Save(user).Error
 }
 
+func (r *AuthRepository) UpdateUserFields(id uint, data map[string]interface{}) error {
+	defer InvalidateCachedUsers(id)
+	return r.db.Model(&models.User{}).Where("id = ?", id).Updates(data).Error
+}
+
+func (r *AuthRepository) RegisterLoginFailure(id uint, now, windowStart time.Time) (int, error) {
+	defer InvalidateCachedUsers(id)
+	var failures int
+	err := r.db.Raw(`UPDATE users SET
+			failed_login_attempts = CASE WHEN last_failed_login_at IS NULL OR last_failed_login_at < ? THEN 1 ELSE failed_login_attempts + 1 END,
+			last_failed_login_at = ?,
+			updated_at = ?
+		WHERE id = ? RETURNING failed_login_attempts`, windowStart, now, now, id).Scan(&failures).Error
+	return failures, err
+}
+
 var _ IAuthRepository = (*AuthRepository)(nil)
@@ -0,0 +1,58 @@
+package repositories
+
+import (
+	"time"
+
+	"zatrano/configs"
+	"zatrano/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ILoginAttemptRepository interface {
+	FindByIP(ip string) (*models.LoginIPAttempt, error)
+	// RegisterFailure, IP'nin başarısız deneme sayacını tek sorguda artırır; son deneme windowStart'tan
+	// önceyse sayaç 1'den başlar.
+	RegisterFailure(ip string, now, windowStart time.Time) (*models.LoginIPAttempt, error)
+	Lock(ip string, until time.Time) error
+}
+
+type LoginAttemptRepository struct {
+	db *gorm.DB
+}
+
+func NewLoginAttemptRepository() ILoginAttemptRepository {
+	return &LoginAttemptRepository{db: configs.GetDB()}
+}
+
+func (r *LoginAttemptRepository) FindByIP(ip string) (*models.LoginIPAttempt, error) {
+	var attempt models.LoginIPAttempt
+	err := r.db.Where("ip = ?", ip).First(&attempt).Error
+	if err != nil {
+		return nil, err
+	}
+	return &attempt, nil
+}
+
+func (r *LoginAttemptRepository) RegisterFailure(ip string, now, windowStart time.Time) (*models.LoginIPAttempt, error) {
+	attempt := models.LoginIPAttempt{IP: ip, FailedCount: 1, LastFailedAt: now}
+	err := r.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "ip"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"failed_count":   gorm.Expr("CASE WHEN login_ip_attempts.last_failed_at < ? THEN 1 ELSE login_ip_attempts.failed_count + 1 END", windowStart),
+			"last_failed_at": now,
+			"updated_at":     now,
+		}),
+	}, clause.Returning{}).Create(&attempt).Error
+	if err != nil {
+		return nil, err
+	}
+	return &attempt, nil
+}
+
+func (r *LoginAttemptRepository) Lock(ip string, until time.Time) error {
+	return r.db.Model(&models.LoginIPAttempt{}).Where("ip = ?", ip).Update("locked_until", until).Error
+}
+
+var _ ILoginAttemptRepository = (*LoginAttemptRepository)(nil)
@@ -0,0 +1,46 @@
+package repositories
+
+import (
+	"zatrano/configs"
+	"zatrano/models"
+
+	"gorm.io/gorm"
+)
+
+type IPasswordHistoryRepository interface {
+	Create(entry *models.PasswordHistory) error
+	FindRecentByUser(userID uint, limit int) ([]models.PasswordHistory, error)
+	DeleteAllButRecent(userID uint, keep int) error
+}
+
+type PasswordHistoryRepository struct {
+	db *gorm.DB
+}
+
+func NewPasswordHistoryRepository() IPasswordHistoryRepository {
+	return &PasswordHistoryRepository{db: configs.GetDB()}
+}
+
+func (r *PasswordHistoryRepository) Create(entry *models.PasswordHistory) error {
+	return r.db.Create(entry).Error
+}
+
+func (r *PasswordHistoryRepository) FindRecentByUser(userID uint, limit int) ([]models.PasswordHistory, error) {
+	var entries []models.PasswordHistory
+	err := r.db.Where("user_id = ?", userID).
+		Order("created_at desc, id desc").
+		Limit(limit).
+		Find(&entries).Error
+	return entries, err
+}
+
+func (r *PasswordHistoryRepository) DeleteAllButRecent(userID uint, keep int) error {
+	recent := r.db.Model(&models.PasswordHistory{}).
+		Select("id").
+		Where("user_id = ?", userID).
+		Order("created_at desc, id desc").
+		Limit(keep)
+	return r.db.Where("user_id = ? AND id NOT IN (?)", userID, recent).Delete(&models.PasswordHistory{}).Error
+}
+
+var _ IPasswordHistoryRepository = (*PasswordHistoryRepository)(nil)
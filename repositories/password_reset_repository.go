@@ -0,0 +1,82 @@
+package repositories
+
+import (
+	"time"
+
+	"zatrano/configs"
+	"zatrano/models"
+
+	"gorm.io/gorm"
+)
+
+type IPasswordResetRepository interface {
+	Create(token *models.PasswordResetToken) error
+	FindByHash(hash string) (*models.PasswordResetToken, error)
+	// Redeem, tokenı kullanır ve kullanıcıyı aynı işlemde günceller; token eşzamanlı bir istekte zaten
+	// kullanılmışsa hiçbir şey değişmez ve false döner.
+	Redeem(id, userID uint, userData map[string]interface{}) (bool, error)
+	InvalidateForUser(userID uint) error
+	DeleteExpired(before time.Time) (int64, error)
+}
+
+type PasswordResetRepository struct {
+	db *gorm.DB
+}
+
+func NewPasswordResetRepository() IPasswordResetRepository {
+	return &PasswordResetRepository{db: configs.GetDB()}
+}
+
+func (r *PasswordResetRepository) Create(token *models.PasswordResetToken) error {
+	return r.db.Create(token).Error
+}
+
+func (r *PasswordResetRepository) FindByHash(hash string) (*models.PasswordResetToken, error) {
+	var token models.PasswordResetToken
+	err := r.db.Where("token_hash = ?", hash).First(&token).Error
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *PasswordResetRepository) Redeem(id, userID uint, userData map[string]interface{}) (bool, error) {
+	defer InvalidateCachedUsers(userID)
+	claimed := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now().UTC()
+		result := tx.Model(&models.PasswordResetToken{}).
+			Where("id = ? AND used_at IS NULL", id).
+			Update("used_at", now)
+		if result.Error != nil || result.RowsAffected != 1 {
+			return result.Error
+		}
+		if err := tx.Model(&models.User{}).Where("id = ?", userID).Updates(userData).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.PasswordResetToken{}).
+			Where("user_id = ? AND used_at IS NULL", userID).
+			Update("used_at", now).Error; err != nil {
+			return err
+		}
+		claimed = true
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return claimed, nil
+}
+
+func (r *PasswordResetRepository) InvalidateForUser(userID uint) error {
+	return r.db.Model(&models.PasswordResetToken{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Update("used_at", time.Now().UTC()).Error
+}
+
+func (r *PasswordResetRepository) DeleteExpired(before time.Time) (int64, error) {
+	result := r.db.Where("expires_at < ?", before).Delete(&models.PasswordResetToken{})
+	return result.RowsAffected, result.Error
+}
+
+var _ IPasswordResetRepository = (*PasswordResetRepository)(nil)
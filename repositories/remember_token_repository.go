@@ -0,0 +1,89 @@
+package repositories
+
+import (
+	"time"
+
+	"zatrano/configs"
+	"zatrano/models"
+
+	"gorm.io/gorm"
+)
+
+type IRememberTokenRepository interface {
+	Create(token *models.RememberToken) error
+	FindBySelector(selector string) (*models.RememberToken, error)
+	Rotate(id uint, currentHash, newHash, ip, userAgent string, now time.Time) (bool, error)
+	CountByUser(userID uint) (int64, error)
+	DeleteBySelector(selector string) error
+	DeleteByUser(userID uint) (int64, error)
+	DeleteByUserExcept(userID uint, keepSelector string) (int64, error)
+	DeleteExpired(before time.Time) (int64, error)
+}
+
+type RememberTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewRememberTokenRepository() IRememberTokenRepository {
+	return &RememberTokenRepository{db: configs.GetDB()}
+}
+
+func (r *RememberTokenRepository) Create(token *models.RememberToken) error {
+	return r.db.Create(token).Error
+}
+
+func (r *RememberTokenRepository) FindBySelector(selector string) (*models.RememberToken, error) {
+	var token models.RememberToken
+	err := r.db.Where("selector = ?", selector).First(&token).Error
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// Rotate, validator'ı yalnızca mevcut özet hâlâ geçerliyse değiştirir; eşzamanlı ikinci kullanım false alır.
+func (r *RememberTokenRepository) Rotate(id uint, currentHash, newHash, ip, userAgent string, now time.Time) (bool, error) {
+	result := r.db.Model(&models.RememberToken{}).
+		Where("id = ? AND validator_hash = ?", id, currentHash).
+		Updates(map[string]interface{}{
+			"validator_hash":          newHash,
+			"previous_validator_hash": currentHash,
+			"rotated_at":              now,
+			"last_used_at":            now,
+			"ip":                      ip,
+			"user_agent":              userAgent,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *RememberTokenRepository) CountByUser(userID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.RememberToken{}).
+		Where("user_id = ? AND expires_at > ?", userID, time.Now().UTC()).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *RememberTokenRepository) DeleteBySelector(selector string) error {
+	return r.db.Where("selector = ?", selector).Delete(&models.RememberToken{}).Error
+}
+
+func (r *RememberTokenRepository) DeleteByUser(userID uint) (int64, error) {
+	result := r.db.Where("user_id = ?", userID).Delete(&models.RememberToken{})
+	return result.RowsAffected, result.Error
+}
+
+func (r *RememberTokenRepository) DeleteByUserExcept(userID uint, keepSelector string) (int64, error) {
+	result := r.db.Where("user_id = ? AND selector <> ?", userID, keepSelector).Delete(&models.RememberToken{})
+	return result.RowsAffected, result.Error
+}
+
+func (r *RememberTokenRepository) DeleteExpired(before time.Time) (int64, error) {
+	result := r.db.Where("expires_at < ?", before).Delete(&models.RememberToken{})
+	return result.RowsAffected, result.Error
+}
+
+var _ IRememberTokenRepository = (*RememberTokenRepository)(nil)
@@ -0,0 +1,136 @@
+package repositories
+
+import (
+	"zatrano/configs"
+	"zatrano/models"
+
+	"gorm.io/gorm"
+)
+
+type IRoleRepository interface {
+	FindAll() ([]models.Role, error)
+	FindByID(id uint) (*models.Role, error)
+	FindBySlug(slug string) (*models.Role, error)
+	FindByIDs(ids []uint) ([]models.Role, error)
+	FindAllPermissions() ([]models.Permission, error)
+	FindPermissionsByKeys(keys []string) ([]models.Permission, error)
+	Create(role *models.Role) error
+	Update(role *models.Role, permissions []models.Permission) error
+	Delete(id uint) error
+	CountUsers(roleID uint) (int64, error)
+	FindPermissionKeysByUser(userID uint) ([]string, error)
+}
+
+type RoleRepository struct {
+	db *gorm.DB
+}
+
+func NewRoleRepository() IRoleRepository {
+	return &RoleRepository{db: configs.GetDB()}
+}
+
+func (r *RoleRepository) FindAll() ([]models.Role, error) {
+	var roles []models.Role
+	err := r.db.Preload("Permissions").Order("built_in DESC, name").Find(&roles).Error
+	return roles, err
+}
+
+func (r *RoleRepository) FindByID(id uint) (*models.Role, error) {
+	var role models.Role
+	err := r.db.Preload("Permissions").First(&role, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &role, nil
+}
+
+func (r *RoleRepository) FindBySlug(slug string) (*models.Role, error) {
+	var role models.Role
+	err := r.db.Preload("Permissions").Where("slug = ?", slug).First(&role).Error
+	if err != nil {
+		return nil, err
+	}
+	return &role, nil
+}
+
+func (r *RoleRepository) FindByIDs(ids []uint) ([]models.Role, error) {
+	var roles []models.Role
+	if len(ids) == 0 {
+		return roles, nil
+	}
+	err := r.db.Preload("Permissions").Where("id IN ?", ids).Find(&roles).Error
+	return roles, err
+}
+
+func (r *RoleRepository) FindAllPermissions() ([]models.Permission, error) {
+	var permissions []models.Permission
+	err := r.db.Order("id").Find(&permissions).Error
+	return permissions, err
+}
+
+func (r *RoleRepository) FindPermissionsByKeys(keys []string) ([]models.Permission, error) {
+	var permissions []models.Permission
+	if len(keys) == 0 {
+		return permissions, nil
+	}
+	err := r.db.Where("key IN ?", keys).Find(&permissions).Error
+	return permissions, err
+}
+
+func (r *RoleRepository) Create(role *models.Role) error {
+	return r.db.Create(role).Error
+}
+
+// Update, rol bilgilerini ve izin listesini tek işlemde günceller.
+func (r *RoleRepository) Update(role *models.Role, permissions []models.Permission) error {
+	defer PurgeUserCache()
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(role).Select("name", "description").Updates(map[string]interface{}{
+			"name":        role.Name,
+			"description": role.Description,
+		}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Model(role).Association("Permissions").Replace(permissions)
+	})
+}
+
+func (r *RoleRepository) Delete(id uint) error {
+	defer PurgeUserCache()
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM user_roles WHERE role_id = ?", id).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM role_permissions WHERE role_id = ?", id).Error; err != nil {
+			return err
+		}
+		result := tx.Delete(&models.Role{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
+
+func (r *RoleRepository) CountUsers(roleID uint) (int64, error) {
+	var count int64
+	err := r.db.Table("user_roles").Where("role_id = ?", roleID).Count(&count).Error
+	return count, err
+}
+
+func (r *RoleRepository) FindPermissionKeysByUser(userID uint) ([]string, error) {
+	var keys []string
+	err := r.db.Table("permissions").
+		Distinct("permissions.key").
+		Joins("JOIN role_permissions ON role_permissions.permission_id = permissions.id").
+		Joins("JOIN user_roles ON user_roles.role_id = role_permissions.role_id").
+		Where("user_roles.user_id = ?", userID).
+		Pluck("permissions.key", &keys).Error
+	return keys, err
+}
+
+var _ IRoleRepository = (*RoleRepository)(nil)
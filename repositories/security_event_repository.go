@@ -0,0 +1,100 @@
+package repositories
+
+import (
+	"strings"
+
+	"zatrano/configs"
+	"zatrano/models"
+	"zatrano/search"
+	"zatrano/utils"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type ISecurityEventRepository interface {
+	Create(event *models.SecurityEvent) error
+	FindRecentByUser(userID uint, limit int) ([]models.SecurityEvent, error)
+	FindAndPaginate(params utils.ListParams, filter utils.SecurityEventFilter) ([]models.SecurityEvent, int64, error)
+}
+
+type SecurityEventRepository struct {
+	db *gorm.DB
+}
+
+func NewSecurityEventRepository() ISecurityEventRepository {
+	return &SecurityEventRepository{db: configs.GetDB()}
+}
+
+func (r *SecurityEventRepository) Create(event *models.SecurityEvent) error {
+	return r.db.Create(event).Error
+}
+
+func (r *SecurityEventRepository) FindRecentByUser(userID uint, limit int) ([]models.SecurityEvent, error) {
+	var events []models.SecurityEvent
+	err := r.db.Where("user_id = ?", userID).
+		Order("created_at desc, id desc").
+		Limit(limit).
+		Find(&events).Error
+	return events, err
+}
+
+func (r *SecurityEventRepository) FindAndPaginate(params utils.ListParams, filter utils.SecurityEventFilter) ([]models.SecurityEvent, int64, error) {
+	var events []models.SecurityEvent
+	var totalCount int64
+
+	query := r.db.Model(&models.SecurityEvent{})
+
+	if params.Name != "" {
+		query = query.Where(search.Contains("account", params.Name))
+	}
+	if filter.UserID > 0 {
+		query = query.Where("user_id = ?", filter.UserID)
+	}
+	if filter.Type != "" {
+		query = query.Where("type = ?", filter.Type)
+	}
+	switch filter.Outcome {
+	case "success":
+		query = query.Where("success = ?", true)
+	case "failure":
+		query = query.Where("success = ?", false)
+	}
+	if filter.IP != "" {
+		query = query.Where("ip = ?", strings.TrimSpace(filter.IP))
+	}
+
+	err := query.Count(&totalCount).Error
+	if err != nil {
+		utils.Log.Error("Güvenlik olayı sayısı alınırken hata (FindAndPaginate)", zap.Error(err))
+		return nil, 0, err
+	}
+
+	if totalCount == 0 {
+		return events, 0, nil
+	}
+
+	sortBy := params.SortBy
+	orderBy := strings.ToLower(params.OrderBy)
+	if orderBy != "asc" && orderBy != "desc" {
+		orderBy = utils.DefaultOrderBy
+	}
+	allowedSortColumns := map[string]bool{"id": true, "account": true, "type": true, "success": true, "ip": true, "created_at": true}
+	if _, ok := allowedSortColumns[sortBy]; !ok {
+		sortBy = utils.DefaultSortBy
+	}
+	query = query.Order(sortBy + " " + orderBy)
+
+	offset := params.CalculateOffset()
+	query = query.Limit(params.PerPage).Offset(offset)
+
+	err = query.Find(&events).Error
+	if err != nil {
+		utils.Log.Error("Güvenlik olayları çekilirken hata (FindAndPaginate)", zap.Error(err))
+		return nil, totalCount, err
+	}
+
+	return events, totalCount, nil
+}
+
+var _ ISecurityEventRepository = (*SecurityEventRepository)(nil)
@@ -0,0 +1,98 @@
+package repositories
+
+import (
+	"zatrano/configs"
+	"zatrano/models"
+
+	"gorm.io/gorm"
+)
+
+// TeamWithCount, takım listesinde üye sayısını da taşır.
+type TeamWithCount struct {
+	models.Team
+	MemberCount int64
+}
+
+type ITeamRepository interface {
+	FindAll() ([]models.Team, error)
+	FindAllWithMemberCount() ([]TeamWithCount, error)
+	FindByID(id uint) (*models.Team, error)
+	FindMembers(teamID uint) ([]models.User, error)
+	Create(team *models.Team) error
+	Update(id uint, data map[string]interface{}) error
+	Delete(id uint) error
+}
+
+type TeamRepository struct {
+	db *gorm.DB
+}
+
+func NewTeamRepository() ITeamRepository {
+	return &TeamRepository{db: configs.GetDB()}
+}
+
+func (r *TeamRepository) FindAll() ([]models.Team, error) {
+	var teams []models.Team
+	err := r.db.Order("name").Find(&teams).Error
+	return teams, err
+}
+
+func (r *TeamRepository) FindAllWithMemberCount() ([]TeamWithCount, error) {
+	var teams []TeamWithCount
+	err := r.db.Model(&models.Team{}).
+		Select("teams.*, (SELECT COUNT(*) FROM users WHERE users.team_id = teams.id AND users.deleted_at IS NULL) AS member_count").
+		Order("teams.name").
+		Scan(&teams).Error
+	return teams, err
+}
+
+func (r *TeamRepository) FindByID(id uint) (*models.Team, error) {
+	var team models.Team
+	err := r.db.First(&team, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &team, nil
+}
+
+func (r *TeamRepository) FindMembers(teamID uint) ([]models.User, error) {
+	var users []models.User
+	err := r.db.Where("team_id = ?", teamID).Order("name").Find(&users).Error
+	return users, err
+}
+
+func (r *TeamRepository) Create(team *models.Team) error {
+	return r.db.Create(team).Error
+}
+
+func (r *TeamRepository) Update(id uint, data map[string]interface{}) error {
+	defer PurgeUserCache()
+	result := r.db.Model(&models.Team{}).Where("id = ?", id).Updates(data)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// Delete, takımı siler; üyelerin team_id alanı yabancı anahtar kuralıyla NULL olur.
+func (r *TeamRepository) Delete(id uint) error {
+	defer PurgeUserCache()
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.User{}).Where("team_id = ?", id).Update("team_id", nil).Error; err != nil {
+			return err
+		}
+		result := tx.Delete(&models.Team{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
+
+var _ ITeamRepository = (*TeamRepository)(nil)
@@ -0,0 +1,77 @@
+package repositories
+
+import (
+	"sync"
+
+	"zatrano/cache"
+	"zatrano/configs"
+	"zatrano/models"
+)
+
+// userCacheKey, aynı kullanıcının ilişkisiz (IAuthRepository) ve ilişkileriyle yüklenmiş
+// (IUserRepository) hallerini ayrı tutar.
+type userCacheKey struct {
+	id               uint
+	withAssociations bool
+}
+
+var (
+	userCacheOnce sync.Once
+	userCache     cache.Cache[userCacheKey, models.User]
+)
+
+func getUserCache() cache.Cache[userCacheKey, models.User] {
+	userCacheOnce.Do(func() {
+		cfg := configs.GetUserCacheConfig()
+		if !cfg.Enabled() {
+			userCache = cache.NewNoopCache[userCacheKey, models.User]()
+			return
+		}
+		userCache = cache.NewMemoryCache[userCacheKey, models.User](cfg.TTL, cfg.MaxEntries)
+	})
+	return userCache
+}
+
+// cachedUser, önbellekteki kaydın kopyasını döner; çağıranın yaptığı değişiklikler önbelleğe yansımaz.
+func cachedUser(id uint, withAssociations bool) (*models.User, bool) {
+	user, ok := getUserCache().Get(userCacheKey{id: id, withAssociations: withAssociations})
+	if !ok {
+		return nil, false
+	}
+	return cloneUser(&user), true
+}
+
+func storeCachedUser(user *models.User, withAssociations bool) {
+	getUserCache().Set(userCacheKey{id: user.ID, withAssociations: withAssociations}, *cloneUser(user))
+}
+
+// InvalidateCachedUsers, verilen kullanıcıların önbellekteki tüm hallerini siler.
+// Kullanıcı satırını değiştiren her yazma işleminden sonra çağrılmalıdır.
+func InvalidateCachedUsers(ids ...uint) {
+	keys := make([]userCacheKey, 0, len(ids)*2)
+	for _, id := range ids {
+		keys = append(keys, userCacheKey{id: id}, userCacheKey{id: id, withAssociations: true})
+	}
+	getUserCache().Delete(keys...)
+}
+
+// PurgeUserCache, birden fazla kullanıcıyı etkileyen toplu değişikliklerde önbelleği tamamen boşaltır.
+func PurgeUserCache() {
+	getUserCache().Purge()
+}
+
+func UserCacheStats() cache.Stats {
+	return getUserCache().Stats()
+}
+
+func cloneUser(user *models.User) *models.User {
+	clone := *user
+	if user.Roles != nil {
+		clone.Roles = append([]models.Role(nil), user.Roles...)
+	}
+	if user.Team != nil {
+		team := *user.Team
+		clone.Team = &team
+	}
+	return &clone
+}
@@ -0,0 +1,75 @@
+package repositories
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"zatrano/models"
+	"zatrano/utils"
+)
+
+type cursorKind int
+
+const (
+	cursorKindString cursorKind = iota
+	cursorKindUint
+	cursorKindBool
+	cursorKindTime
+)
+
+// userCursorColumn, keyset sayfalamada bir sıralama sütununun karşılaştırma ifadesi ve değer türüdür.
+type userCursorColumn struct {
+	expr  string
+	kind  cursorKind
+	value func(user *models.User) string
+}
+
+var userCursorColumns = map[string]userCursorColumn{
+	"id":      {expr: "id", kind: cursorKindUint, value: func(u *models.User) string { return strconv.FormatUint(uint64(u.ID), 10) }},
+	"name":    {expr: "name", kind: cursorKindString, value: func(u *models.User) string { return u.Name }},
+	"account": {expr: "account", kind: cursorKindString, value: func(u *models.User) string { return u.Account }},
+	// NULL team_id satır karşılaştırmasını bozmasın diye sıfıra çekilir.
+	"team_id": {expr: "COALESCE(team_id, 0)", kind: cursorKindUint, value: func(u *models.User) string {
+		if u.TeamID == nil {
+			return "0"
+		}
+		return strconv.FormatUint(uint64(*u.TeamID), 10)
+	}},
+	"created_at": {expr: "created_at", kind: cursorKindTime, value: func(u *models.User) string { return u.CreatedAt.UTC().Format(time.RFC3339Nano) }},
+	"status":     {expr: "status", kind: cursorKindBool, value: func(u *models.User) string { return strconv.FormatBool(u.Status) }},
+	"type":       {expr: "type", kind: cursorKindString, value: func(u *models.User) string { return string(u.Type) }},
+	"deleted_at": {expr: "deleted_at", kind: cursorKindTime, value: func(u *models.User) string { return u.DeletedAt.Time.UTC().Format(time.RFC3339Nano) }},
+}
+
+// cursorSort, keyset sayfalamada kullanılacak sıralama sütununu ve yönünü döner; bilinmeyen sütunlarda varsayılana düşer.
+func cursorSort(params utils.ListParams) (string, string, userCursorColumn) {
+	sortBy := params.SortBy
+	orderBy := strings.ToLower(params.OrderBy)
+	if orderBy != "asc" && orderBy != "desc" {
+		orderBy = utils.DefaultOrderBy
+	}
+	column, ok := userCursorColumns[sortBy]
+	if !ok {
+		sortBy = utils.DefaultSortBy
+		column = userCursorColumns[sortBy]
+	}
+	return sortBy, orderBy, column
+}
+
+// parse, imleçteki metin değeri sütunun türüne çevirir.
+func (col userCursorColumn) parse(value string) (interface{}, error) {
+	switch col.kind {
+	case cursorKindUint:
+		return strconv.ParseUint(value, 10, 64)
+	case cursorKindBool:
+		return strconv.ParseBool(value)
+	case cursorKindTime:
+		return time.Parse(time.RFC3339Nano, value)
+	}
+	return value, nil
+}
+
+func (col userCursorColumn) cursor(user *models.User, sortBy, orderBy string, backward bool) string {
+	return utils.Cursor{SortBy: sortBy, OrderBy: orderBy, Value: col.value(user), ID: user.ID, Backward: backward}.Encode()
+}
@@ -0,0 +1,76 @@
+package repositories
+
+import (
+	"time"
+
+	"zatrano/configs"
+	"zatrano/models"
+
+	"gorm.io/gorm"
+)
+
+type IUserInvitationRepository interface {
+	Create(invitation *models.UserInvitation) error
+	FindByHash(hash string) (*models.UserInvitation, error)
+	// Redeem, daveti kullanır ve kullanıcıyı aynı işlemde günceller; davet eşzamanlı bir istekte zaten
+	// kullanılmışsa hiçbir şey değişmez ve false döner.
+	Redeem(id, userID uint, userData map[string]interface{}) (bool, error)
+	InvalidateForUser(userID uint) error
+}
+
+type UserInvitationRepository struct {
+	db *gorm.DB
+}
+
+func NewUserInvitationRepository() IUserInvitationRepository {
+	return &UserInvitationRepository{db: configs.GetDB()}
+}
+
+func (r *UserInvitationRepository) Create(invitation *models.UserInvitation) error {
+	return r.db.Create(invitation).Error
+}
+
+func (r *UserInvitationRepository) FindByHash(hash string) (*models.UserInvitation, error) {
+	var invitation models.UserInvitation
+	err := r.db.Where("token_hash = ?", hash).First(&invitation).Error
+	if err != nil {
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+func (r *UserInvitationRepository) Redeem(id, userID uint, userData map[string]interface{}) (bool, error) {
+	defer InvalidateCachedUsers(userID)
+	claimed := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now().UTC()
+		result := tx.Model(&models.UserInvitation{}).
+			Where("id = ? AND used_at IS NULL", id).
+			Update("used_at", now)
+		if result.Error != nil || result.RowsAffected != 1 {
+			return result.Error
+		}
+		if err := tx.Model(&models.User{}).Where("id = ?", userID).Updates(userData).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.UserInvitation{}).
+			Where("user_id = ? AND used_at IS NULL", userID).
+			Update("used_at", now).Error; err != nil {
+			return err
+		}
+		claimed = true
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return claimed, nil
+}
+
+func (r *UserInvitationRepository) InvalidateForUser(userID uint) error {
+	return r.db.Model(&models.UserInvitation{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Update("used_at", time.Now().UTC()).Error
+}
+
+var _ IUserInvitationRepository = (*UserInvitationRepository)(nil)
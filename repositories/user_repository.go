@@ -1,10 +1,15 @@
 package repositories
 
 import (
+	"database/sql"
+	"encoding/json"
+	"errors"
 	"strings"
+	"time"
 
 	"zatrano/configs"
 	"zatrano/models"
+	"zatrano/search"
 	"zatrano/utils"
 
 	"go.uber.org/zap"
@@ -12,13 +17,34 @@ import (
 	"gorm.io/gorm/clause"
 )
 
+// ErrNoActiveAdmin, işlem giriş yapabilen hiçbir sistem yöneticisi bırakmayacağında döner.
+var ErrNoActiveAdmin = errors.New("aktif sistem yöneticisi kalmayacak")
+
 type IUserRepository interface {
-	FindAndPaginate(params utils.ListParams) ([]models.User, int64, error)
+	FindAndPaginate(params utils.ListParams, filter utils.UserFilter) ([]models.User, int64, error)
+	FindInBatches(params utils.ListParams, filter utils.UserFilter, batchSize int, fn func([]models.User) error) error
+	FindByCursor(params utils.ListParams, filter utils.UserFilter) (users []models.User, next, prev string, err error)
+	CountFiltered(params utils.ListParams, filter utils.UserFilter, estimateAbove int64) (count int64, estimated bool, err error)
 	FindByID(id uint) (*models.User, error)
 	Create(user *models.User) error
 	Update(id uint, data map[string]interface{}) error
-	Delete(id uint) error
+	// UpdateWithRoles, satırı ve roles nil değilse rol bağlantılarını tek işlemde günceller. keepAdmin verilirse
+	// aynı işlemde kullanıcı dışında aktif sistem yöneticisi kalmadığında ErrNoActiveAdmin döner.
+	UpdateWithRoles(id uint, data map[string]interface{}, roles []models.Role, keepAdmin bool) error
+	// Delete, kullanıcıyı siler (soft delete); keepAdmin, UpdateWithRoles'taki denetimi aynı işlemde yapar.
+	Delete(id uint, keepAdmin bool) error
 	Count() (int64, error)
+	CountActiveAdmins(excludeIDs ...uint) (int64, error)
+	FindDeletedByID(id uint) (*models.User, error)
+	ExistsActiveAccount(account string) (bool, error)
+	Restore(id uint) error
+	Purge(ids ...uint) (int64, error)
+	AvatarKeys(ids ...uint) []string
+	FindDeletedIDsBefore(cutoff time.Time, limit int) ([]uint, error)
+	FindByIDs(ids []uint) ([]models.User, error)
+	BulkUpdate(ids []uint, data map[string]interface{}) error
+	BulkUpdateWithRole(ids []uint, data map[string]interface{}, roleID uint) error
+	BulkDelete(ids []uint) error
 }
 
 type UserRepository struct {
@@ -29,16 +55,11 @@ func NewUserRepository() IUserRepository {
 	return &UserRepository{db: configs.GetDB()}
 }
 
-func (r *UserRepository) FindAndPaginate(params utils.ListParams) ([]models.User, int64, error) {
+func (r *UserRepository) FindAndPaginate(params utils.ListParams, filter utils.UserFilter) ([]models.User, int64, error) {
 	var users []models.User
 	var totalCount int64
 
-	query := r.db.Model(&models.User{}).Where("id != ?", 1)
-
-	if params.Name != "" {
-		sqlQueryFragment, queryParams := utils.SQLFilter("name", params.Name)
-		query = query.Where(sqlQueryFragment, queryParams...)
-	}
+	query := r.filteredQuery(r.db, params, filter)
 
 	err := query.Count(&totalCount).Error
 	if err != nil {
@@ -50,43 +71,285 @@ func (r *UserRepository) FindAndPaginate(params utils.ListParams) ([]models.User
 		return users, 0, nil
 	}
 
+	query = orderUsers(query, params)
+
+	query = query.Preload(clause.Associations)
+
+	offset := params.CalculateOffset()
+	query = query.Limit(params.PerPage).Offset(offset)
+
+	err = query.Find(&users).Error
+	if err != nil {
+		utils.Log.Error("Kullanıcı verisi çekilirken hata (FindAndPaginate)", zap.Error(err))
+		return nil, totalCount, err
+	}
+
+	return users, totalCount, nil
+}
+
+// FindInBatches, filtreye uyan tüm kullanıcıları tek bir REPEATABLE READ işlem içinde "(sıralama sütunu, id)"
+// keyset'iyle gruplar halinde fn'e iletir. Benzerlik sıralaması bir sütuna dayanmadığından arama sonuçları OFFSET ile okunur.
+func (r *UserRepository) FindInBatches(params utils.ListParams, filter utils.UserFilter, batchSize int, fn func([]models.User) error) error {
+	if batchSize <= 0 {
+		batchSize = utils.MaxPerPage
+	}
+	relevance := params.SortBy == utils.SortByRelevance && params.Name != ""
+	_, orderBy, column := cursorSort(params)
+	operator := "<"
+	if orderBy == "asc" {
+		operator = ">"
+	}
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var last *models.User
+		for offset := 0; ; offset += batchSize {
+			query := r.filteredQuery(tx, params, filter)
+			if relevance {
+				query = orderUsers(query, params).Offset(offset)
+			} else {
+				if last != nil {
+					value, err := column.parse(column.value(last))
+					if err != nil {
+						return err
+					}
+					query = query.Where("("+column.expr+", id) "+operator+" (?, ?)", value, last.ID)
+				}
+				query = query.Order(column.expr + " " + orderBy).Order("id " + orderBy)
+			}
+
+			var users []models.User
+			err := query.
+				Preload(clause.Associations).
+				Limit(batchSize).
+				Find(&users).Error
+			if err != nil {
+				utils.Log.Error("Kullanıcı verisi çekilirken hata (FindInBatches)", zap.Error(err), zap.Int("offset", offset))
+				return err
+			}
+			if len(users) == 0 {
+				return nil
+			}
+			if err := fn(users); err != nil {
+				return err
+			}
+			if len(users) < batchSize {
+				return nil
+			}
+			last = &users[len(users)-1]
+		}
+	}, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+}
+
+// filteredQuery, kullanıcı listesi ve dışa aktarma için ortak WHERE koşullarını kurar.
+func (r *UserRepository) filteredQuery(db *gorm.DB, params utils.ListParams, filter utils.UserFilter) *gorm.DB {
+	query := db.Model(&models.User{}).Where("protected = ?", false)
+	if filter.OnlyDeleted {
+		query = db.Unscoped().Model(&models.User{}).Where("protected = ? AND deleted_at IS NOT NULL", false)
+	}
+
+	if params.Name != "" {
+		query = query.Where(search.Matches(params.Name, userSearchColumns...))
+	}
+	if filter.TeamID != 0 {
+		query = query.Where("team_id = ?", filter.TeamID)
+	}
+	if filter.ScopeTeamID != nil {
+		query = query.Where("team_id = ?", *filter.ScopeTeamID)
+	}
+	if filter.Account != "" {
+		query = query.Where(search.Contains("account", filter.Account))
+	}
+	switch filter.Status {
+	case utils.UserStatusActive:
+		query = query.Where("status = ?", true)
+	case utils.UserStatusInactive:
+		query = query.Where("status = ?", false)
+	}
+	// Enum dışı bir değer Postgres'te sorgu hatasına yol açacağı için yalnızca bilinen tipler uygulanır.
+	if userType := models.UserType(filter.Type); userType == models.System || userType == models.Panel {
+		query = query.Where("type = ?", userType)
+	}
+	createdFrom, createdTo := filter.CreatedRange()
+	if createdFrom != nil {
+		query = query.Where("created_at >= ?", *createdFrom)
+	}
+	if createdTo != nil {
+		query = query.Where("created_at < ?", *createdTo)
+	}
+	return query
+}
+
+// userSearchColumns, listedeki arama kutusunun birlikte aradığı sütunlardır.
+var userSearchColumns = []string{"name", "account"}
+
+// orderUsers, izin verilen sütuna ve ikincil olarak id'ye göre, aramada istenirse benzerliğe göre sıralar.
+func orderUsers(query *gorm.DB, params utils.ListParams) *gorm.DB {
+	if params.SortBy == utils.SortByRelevance && params.Name != "" {
+		// GORM, ifade tabanlı bir ORDER BY'ı sonraki Order çağrılarıyla birleştiremediği için id aynı ifadeye yazılır.
+		return query.Order(clause.OrderBy{Expression: clause.Expr{
+			SQL:                "? DESC, id",
+			Vars:               []interface{}{search.Rank(params.Name, userSearchColumns...)},
+			WithoutParentheses: true,
+		}})
+	}
+
 	sortBy := params.SortBy
 	orderBy := strings.ToLower(params.OrderBy)
 	if orderBy != "asc" && orderBy != "desc" {
 		orderBy = utils.DefaultOrderBy
 	}
-	allowedSortColumns := map[string]bool{"id": true, "name": true, "account": true, "team_id": true, "created_at": true, "status": true, "type": true}
+	allowedSortColumns := map[string]bool{"id": true, "name": true, "account": true, "team_id": true, "created_at": true, "status": true, "type": true, "deleted_at": true}
 	if _, ok := allowedSortColumns[sortBy]; !ok {
 		sortBy = utils.DefaultSortBy
 	}
-	orderClause := sortBy + " " + orderBy
-	query = query.Order(orderClause)
+	return query.Order(sortBy + " " + orderBy).Order("id")
+}
 
-	query = query.Preload(clause.Associations)
+// FindByCursor, FindAndPaginate'in keyset karşılığıdır; geçersiz imleç yok sayılır ve ilk sayfa döner.
+func (r *UserRepository) FindByCursor(params utils.ListParams, filter utils.UserFilter) ([]models.User, string, string, error) {
+	sortBy, orderBy, column := cursorSort(params)
 
-	offset := params.CalculateOffset()
-	query = query.Limit(params.PerPage).Offset(offset)
+	query := r.filteredQuery(r.db, params, filter)
 
-	err = query.Find(&users).Error
+	var cursor *utils.Cursor
+	if params.Cursor != "" {
+		decoded, valid := utils.DecodeCursor(params.Cursor)
+		value, parseErr := column.parse(decoded.Value)
+		if valid && parseErr == nil && decoded.SortBy == sortBy && decoded.OrderBy == orderBy {
+			cursor = &decoded
+			ascending := (orderBy == "asc") != cursor.Backward
+			operator := "<"
+			if ascending {
+				operator = ">"
+			}
+			query = query.Where("("+column.expr+", id) "+operator+" (?, ?)", value, cursor.ID)
+		} else {
+			utils.Log.Warn("Geçersiz veya güncel olmayan sayfalama imleci yok sayıldı (FindByCursor)", zap.String("sort_by", sortBy))
+		}
+	}
+
+	direction := orderBy
+	if cursor != nil && cursor.Backward {
+		direction = map[string]string{"asc": "desc", "desc": "asc"}[orderBy]
+	}
+
+	var users []models.User
+	err := query.
+		Order(column.expr + " " + direction).
+		Order("id " + direction).
+		Preload(clause.Associations).
+		Limit(params.PerPage + 1).
+		Find(&users).Error
 	if err != nil {
-		utils.Log.Error("Kullanıcı verisi çekilirken hata (FindAndPaginate)", zap.Error(err))
-		return nil, totalCount, err
+		utils.Log.Error("Kullanıcı verisi çekilirken hata (FindByCursor)", zap.Error(err))
+		return nil, "", "", err
 	}
 
-	return users, totalCount, nil
+	hasMore := len(users) > params.PerPage
+	if hasMore {
+		users = users[:params.PerPage]
+	}
+	backward := cursor != nil && cursor.Backward
+	if backward {
+		for i, j := 0, len(users)-1; i < j; i, j = i+1, j-1 {
+			users[i], users[j] = users[j], users[i]
+		}
+	}
+	if len(users) == 0 {
+		return users, "", "", nil
+	}
+
+	first, last := &users[0], &users[len(users)-1]
+	var next, prev string
+	if backward {
+		// Geri gelindiyse ileride en az bir sayfa vardır; geride sayfa olup olmadığını fazladan okunan kayıt söyler.
+		next = column.cursor(last, sortBy, orderBy, false)
+		if hasMore {
+			prev = column.cursor(first, sortBy, orderBy, true)
+		}
+	} else {
+		if hasMore {
+			next = column.cursor(last, sortBy, orderBy, false)
+		}
+		if cursor != nil {
+			prev = column.cursor(first, sortBy, orderBy, true)
+		}
+	}
+	return users, next, prev, nil
+}
+
+// CountFiltered, tablo estimateAbove satırı aşıyorsa planlayıcının tahminini, aksi halde kesin sayımı döner.
+func (r *UserRepository) CountFiltered(params utils.ListParams, filter utils.UserFilter, estimateAbove int64) (int64, bool, error) {
+	if estimateAbove > 0 {
+		var tableRows int64
+		err := r.db.Raw("SELECT reltuples::bigint FROM pg_class WHERE oid = 'users'::regclass").Scan(&tableRows).Error
+		if err != nil {
+			utils.Log.Warn("Kullanıcı tablosu istatistikleri okunamadı, kesin sayım yapılacak", zap.Error(err))
+		} else if tableRows >= estimateAbove {
+			estimate, err := r.estimateFilteredRows(params, filter)
+			if err == nil {
+				return estimate, true, nil
+			}
+			utils.Log.Warn("Kullanıcı sayısı tahmini alınamadı, kesin sayım yapılacak", zap.Error(err))
+		}
+	}
+
+	var count int64
+	if err := r.filteredQuery(r.db, params, filter).Count(&count).Error; err != nil {
+		utils.Log.Error("Kullanıcı sayısı alınırken hata (CountFiltered)", zap.Error(err))
+		return 0, false, err
+	}
+	return count, false, nil
+}
+
+// estimateFilteredRows, filtreli sorgunun EXPLAIN çıktısındaki "Plan Rows" değerini döner.
+func (r *UserRepository) estimateFilteredRows(params utils.ListParams, filter utils.UserFilter) (int64, error) {
+	stmt := r.filteredQuery(r.db.Session(&gorm.Session{DryRun: true}), params, filter).Find(&[]models.User{}).Statement
+	sqlDB, err := r.db.DB()
+	if err != nil {
+		return 0, err
+	}
+
+	var raw string
+	if err := sqlDB.QueryRow("EXPLAIN (FORMAT JSON) "+stmt.SQL.String(), stmt.Vars...).Scan(&raw); err != nil {
+		return 0, err
+	}
+	var plans []struct {
+		Plan struct {
+			Rows float64 `json:"Plan Rows"`
+		} `json:"Plan"`
+	}
+	if err := json.Unmarshal([]byte(raw), &plans); err != nil {
+		return 0, err
+	}
+	if len(plans) == 0 {
+		return 0, errors.New("boş EXPLAIN çıktısı")
+	}
+	return int64(plans[0].Plan.Rows), nil
 }
 
 func (r *UserRepository) FindByID(id uint) (*models.User, error) {
+	if user, ok := cachedUser(id, true); ok {
+		return user, nil
+	}
+
 	var user models.User
 	err := r.db.Preload(clause.Associations).First(&user, id).Error
+	if err == nil {
+		storeCachedUser(&user, true)
+	}
 	return &user, err
 }
 
+// Create, kullanıcıyı ve user.Roles'taki rol bağlantılarını tek işlemde oluşturur; rol kayıtlarına dokunulmaz.
 func (r *UserRepository) Create(user *models.User) error {
-	return r.db.Create(user).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return tx.Omit("Roles.*").Create(user).Error
+	})
 }
 
 func (r *UserRepository) Update(id uint, data map[string]interface{}) error {
+	defer InvalidateCachedUsers(id)
 	result := r.db.Model(&models.User{}).Where("id = ?", id).Updates(data)
 	if result.Error != nil {
 		return result.Error
@@ -97,14 +360,69 @@ func (r *UserRepository) Update(id uint, data map[string]interface{}) error {
 	return nil
 }
 
-func (r *UserRepository) Delete(id uint) error {
-	result := r.db.Delete(&models.User{}, id)
-	if result.Error != nil {
-		return result.Error
+func (r *UserRepository) UpdateWithRoles(id uint, data map[string]interface{}, roles []models.Role, keepAdmin bool) error {
+	defer InvalidateCachedUsers(id)
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if keepAdmin {
+			if err := ensureAdminRemains(tx, id); err != nil {
+				return err
+			}
+		}
+		result := tx.Model(&models.User{}).Where("id = ?", id).Updates(data)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		if roles == nil {
+			return nil
+		}
+		user := models.User{}
+		user.ID = id
+		return tx.Model(&user).Omit("Roles.*").Association("Roles").Replace(roles)
+	})
+}
+
+func (r *UserRepository) Delete(id uint, keepAdmin bool) error {
+	defer InvalidateCachedUsers(id)
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if keepAdmin {
+			if err := ensureAdminRemains(tx, id); err != nil {
+				return err
+			}
+		}
+		result := tx.Delete(&models.User{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			utils.Log.Warn("UserRepository.Delete: Silinecek kullanıcı bulunamadı", zap.Uint("user_id", id))
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
+
+// ensureAdminRemains, tx içinde aktif sistem yöneticilerinin satırlarını kilitler ve excludeID dışında hiçbiri
+// kalmıyorsa ErrNoActiveAdmin döner. Kilit, yönetici kaldıran eşzamanlı işlemleri sıraya sokar; sayım kilit
+// alındıktan sonra ayrı bir sorguyla yapıldığından önceki işlemin kaydettiği değişiklikleri görür.
+func ensureAdminRemains(tx *gorm.DB, excludeID uint) error {
+	var lockedIDs []uint
+	err := activeAdmins(tx).
+		Clauses(clause.Locking{Strength: "UPDATE", Table: clause.Table{Name: "users"}}).
+		Order("users.id").
+		Pluck("users.id", &lockedIDs).Error
+	if err != nil {
+		return err
 	}
-	if result.RowsAffected == 0 {
-		utils.Log.Warn("UserRepository.Delete: Silinecek kullanıcı bulunamadı", zap.Uint("user_id", id))
-		return gorm.ErrRecordNotFound
+
+	var remaining int64
+	if err := activeAdmins(tx).Where("users.id <> ?", excludeID).Distinct("users.id").Count(&remaining).Error; err != nil {
+		return err
+	}
+	if remaining == 0 {
+		return ErrNoActiveAdmin
 	}
 	return nil
 }
@@ -115,4 +433,190 @@ func (r *UserRepository) Count() (int64, error) {
 	return count, err
 }
 
+// CountActiveAdmins, verilen kullanıcılar dışında giriş yapabilen ve yerleşik sistem rolüne sahip kullanıcıları sayar.
+func (r *UserRepository) CountActiveAdmins(excludeIDs ...uint) (int64, error) {
+	var count int64
+	query := activeAdmins(r.db)
+	if len(excludeIDs) > 0 {
+		query = query.Where("users.id NOT IN ?", excludeIDs)
+	}
+	err := query.Distinct("users.id").Count(&count).Error
+	return count, err
+}
+
+// activeAdmins, giriş yapabilen ve yerleşik sistem rolüne sahip kullanıcıları seçen sorguyu döner.
+func activeAdmins(db *gorm.DB) *gorm.DB {
+	return db.Model(&models.User{}).
+		Joins("JOIN user_roles ON user_roles.user_id = users.id").
+		Joins("JOIN roles ON roles.id = user_roles.role_id").
+		Where("roles.slug = ? AND roles.built_in = ?", models.RoleSlugSystem, true).
+		Where("users.status = ? AND users.pending = ?", true, false)
+}
+
+func (r *UserRepository) FindDeletedByID(id uint) (*models.User, error) {
+	var user models.User
+	err := r.db.Unscoped().Preload(clause.Associations).Where("deleted_at IS NOT NULL").First(&user, id).Error
+	return &user, err
+}
+
+func (r *UserRepository) ExistsActiveAccount(account string) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.User{}).Where("account = ?", account).Count(&count).Error
+	return count > 0, err
+}
+
+func (r *UserRepository) Restore(id uint) error {
+	defer InvalidateCachedUsers(id)
+	result := r.db.Unscoped().Model(&models.User{}).Where("id = ? AND deleted_at IS NOT NULL", id).Update("deleted_at", nil)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// AvatarKeys, silinmiş olanlar dahil kullanıcıların profil resmi anahtarlarını döner; Purge'dan önce çağrılır.
+func (r *UserRepository) AvatarKeys(ids ...uint) []string {
+	var keys []string
+	err := r.db.Unscoped().Model(&models.User{}).
+		Where("id IN ? AND avatar_key <> ''", ids).
+		Pluck("avatar_key", &keys).Error
+	if err != nil {
+		utils.Log.Warn("Profil resmi anahtarları alınamadı", zap.Error(err))
+		return nil
+	}
+	return keys
+}
+
+// Purge, silinmiş kullanıcıları ve kimlik bilgisi kayıtlarını siler; güvenlik olayları denetim izi olarak kalır.
+func (r *UserRepository) Purge(ids ...uint) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	defer InvalidateCachedUsers(ids...)
+
+	var purged int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var deletedIDs []uint
+		if err := tx.Unscoped().Model(&models.User{}).Where("id IN ? AND deleted_at IS NOT NULL", ids).Pluck("id", &deletedIDs).Error; err != nil {
+			return err
+		}
+		if len(deletedIDs) == 0 {
+			return nil
+		}
+
+		related := []interface{}{
+			&models.PasswordHistory{},
+			&models.PasswordResetToken{},
+			&models.RememberToken{},
+			&models.RecoveryCode{},
+			&models.UserSession{},
+			&models.UserInvitation{},
+		}
+		for _, model := range related {
+			if err := tx.Unscoped().Where("user_id IN ?", deletedIDs).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Exec("DELETE FROM user_roles WHERE user_id IN ?", deletedIDs).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.SecurityEvent{}).Where("user_id IN ?", deletedIDs).Update("user_id", nil).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.UserInvitation{}).Where("invited_by_id IN ?", deletedIDs).Update("invited_by_id", nil).Error; err != nil {
+			return err
+		}
+
+		result := tx.Unscoped().Where("id IN ?", deletedIDs).Delete(&models.User{})
+		if result.Error != nil {
+			return result.Error
+		}
+		purged = result.RowsAffected
+		return nil
+	})
+	return purged, err
+}
+
+func (r *UserRepository) FindDeletedIDsBefore(cutoff time.Time, limit int) ([]uint, error) {
+	var ids []uint
+	err := r.db.Unscoped().Model(&models.User{}).
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", cutoff).
+		Order("deleted_at").
+		Limit(limit).
+		Pluck("id", &ids).Error
+	return ids, err
+}
+
+func (r *UserRepository) FindByIDs(ids []uint) ([]models.User, error) {
+	var users []models.User
+	if len(ids) == 0 {
+		return users, nil
+	}
+	err := r.db.Preload("Roles").Where("id IN ?", ids).Find(&users).Error
+	return users, err
+}
+
+// BulkUpdate, kullanıcıları tek işlemde günceller; biri bulunamazsa hiçbiri değişmez ve gorm.ErrRecordNotFound döner.
+func (r *UserRepository) BulkUpdate(ids []uint, data map[string]interface{}) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	defer InvalidateCachedUsers(ids...)
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return bulkUpdate(tx, ids, data)
+	})
+}
+
+// BulkUpdateWithRole, BulkUpdate'e ek olarak kullanıcıların rollerini aynı işlemde roleID ile değiştirir.
+func (r *UserRepository) BulkUpdateWithRole(ids []uint, data map[string]interface{}, roleID uint) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	defer InvalidateCachedUsers(ids...)
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := bulkUpdate(tx, ids, data); err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM user_roles WHERE user_id IN ?", ids).Error; err != nil {
+			return err
+		}
+		return tx.Exec("INSERT INTO user_roles (user_id, role_id) SELECT id, ? FROM users WHERE id IN ?", roleID, ids).Error
+	})
+}
+
+func bulkUpdate(tx *gorm.DB, ids []uint, data map[string]interface{}) error {
+	result := tx.Model(&models.User{}).Where("id IN ?", ids).Updates(data)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected != int64(len(ids)) {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// BulkDelete, kullanıcıları BulkUpdate ile aynı hepsi-ya-da-hiçbiri kuralıyla siler (soft delete).
+func (r *UserRepository) BulkDelete(ids []uint) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	defer InvalidateCachedUsers(ids...)
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("id IN ?", ids).Delete(&models.User{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected != int64(len(ids)) {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
+
 var _ IUserRepository = (*UserRepository)(nil)
@@ -0,0 +1,92 @@
+package repositories
+
+import (
+	"time"
+
+	"zatrano/configs"
+	"zatrano/models"
+
+	"gorm.io/gorm"
+)
+
+type IUserSessionRepository interface {
+	Create(userSession *models.UserSession) error
+	FindByHash(hash string) (*models.UserSession, error)
+	FindByID(userID, id uint) (*models.UserSession, error)
+	FindActiveByUser(userID uint, activeSince time.Time) ([]models.UserSession, error)
+	TouchIfOlder(id uint, now, olderThan time.Time) error
+	DeleteByID(userID, id uint) (int64, error)
+	DeleteByHash(hash string) error
+	DeleteByUser(userID uint) (int64, error)
+	DeleteByUserExcept(userID uint, keepHash string) (int64, error)
+	DeleteStale(userID uint, before time.Time) error
+}
+
+type UserSessionRepository struct {
+	db *gorm.DB
+}
+
+func NewUserSessionRepository() IUserSessionRepository {
+	return &UserSessionRepository{db: configs.GetDB()}
+}
+
+func (r *UserSessionRepository) Create(userSession *models.UserSession) error {
+	return r.db.Create(userSession).Error
+}
+
+func (r *UserSessionRepository) FindByHash(hash string) (*models.UserSession, error) {
+	var userSession models.UserSession
+	err := r.db.Where("session_hash = ?", hash).First(&userSession).Error
+	if err != nil {
+		return nil, err
+	}
+	return &userSession, nil
+}
+
+func (r *UserSessionRepository) FindByID(userID, id uint) (*models.UserSession, error) {
+	var userSession models.UserSession
+	err := r.db.Where("id = ? AND user_id = ?", id, userID).First(&userSession).Error
+	if err != nil {
+		return nil, err
+	}
+	return &userSession, nil
+}
+
+func (r *UserSessionRepository) FindActiveByUser(userID uint, activeSince time.Time) ([]models.UserSession, error) {
+	var sessions []models.UserSession
+	err := r.db.Where("user_id = ? AND last_activity_at >= ?", userID, activeSince).
+		Order("last_activity_at desc").
+		Find(&sessions).Error
+	return sessions, err
+}
+
+func (r *UserSessionRepository) TouchIfOlder(id uint, now, olderThan time.Time) error {
+	return r.db.Model(&models.UserSession{}).
+		Where("id = ? AND last_activity_at < ?", id, olderThan).
+		Update("last_activity_at", now).Error
+}
+
+func (r *UserSessionRepository) DeleteByID(userID, id uint) (int64, error) {
+	result := r.db.Where("user_id = ? AND id = ?", userID, id).Delete(&models.UserSession{})
+	return result.RowsAffected, result.Error
+}
+
+func (r *UserSessionRepository) DeleteByHash(hash string) error {
+	return r.db.Where("session_hash = ?", hash).Delete(&models.UserSession{}).Error
+}
+
+func (r *UserSessionRepository) DeleteByUser(userID uint) (int64, error) {
+	result := r.db.Where("user_id = ?", userID).Delete(&models.UserSession{})
+	return result.RowsAffected, result.Error
+}
+
+func (r *UserSessionRepository) DeleteByUserExcept(userID uint, keepHash string) (int64, error) {
+	result := r.db.Where("user_id = ? AND session_hash <> ?", userID, keepHash).Delete(&models.UserSession{})
+	return result.RowsAffected, result.Error
+}
+
+func (r *UserSessionRepository) DeleteStale(userID uint, before time.Time) error {
+	return r.db.Where("user_id = ? AND last_activity_at < ?", userID, before).Delete(&models.UserSession{}).Error
+}
+
+var _ IUserSessionRepository = (*UserSessionRepository)(nil)
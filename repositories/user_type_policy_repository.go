@@ -0,0 +1,49 @@
+package repositories
+
+import (
+	"zatrano/configs"
+	"zatrano/models"
+
+	"gorm.io/gorm"
+)
+
+type IUserTypePolicyRepository interface {
+	FindAll() ([]models.UserTypePolicy, error)
+	FindByType(userType models.UserType) (*models.UserTypePolicy, error)
+	Save(policy *models.UserTypePolicy) error
+}
+
+type UserTypePolicyRepository struct {
+	db *gorm.DB
+}
+
+func NewUserTypePolicyRepository() IUserTypePolicyRepository {
+	return &UserTypePolicyRepository{db: configs.GetDB()}
+}
+
+func (r *UserTypePolicyRepository) FindAll() ([]models.UserTypePolicy, error) {
+	var policies []models.UserTypePolicy
+	err := r.db.Order("type").Find(&policies).Error
+	return policies, err
+}
+
+func (r *UserTypePolicyRepository) FindByType(userType models.UserType) (*models.UserTypePolicy, error) {
+	var policy models.UserTypePolicy
+	err := r.db.Where("type = ?", userType).First(&policy).Error
+	if err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+// Save, yeni kayıtlarda false/0 alanlarının sütun varsayılanlarıyla ezilmemesi için önce oluşturup sonra tüm alanları yazar.
+func (r *UserTypePolicyRepository) Save(policy *models.UserTypePolicy) error {
+	if policy.ID == 0 {
+		if err := r.db.Create(policy).Error; err != nil {
+			return err
+		}
+	}
+	return r.db.Save(policy).Error
+}
+
+var _ IUserTypePolicyRepository = (*UserTypePolicyRepository)(nil)
@@ -14,8 +14,28 @@ func registerAuthRoutes(app *fiber.App) {
 
 	authGroup.Get("/login", middlewares.GuestMiddleware, authHandler.ShowLogin)
 	authGroup.Post("/login", middlewares.GuestMiddleware, authHandler.Login)
+	authGroup.Get("/2fa", middlewares.GuestMiddleware, authHandler.ShowTwoFactorChallenge)
+	authGroup.Post("/2fa", middlewares.GuestMiddleware, authHandler.VerifyTwoFactorChallenge)
+	authGroup.Get("/forgot", middlewares.GuestMiddleware, authHandler.ShowForgotPassword)
+	authGroup.Post("/forgot", middlewares.GuestMiddleware, authHandler.ForgotPassword)
+	authGroup.Get("/reset/:token", middlewares.GuestMiddleware, authHandler.ShowResetPassword)
+	authGroup.Post("/reset/:token", middlewares.GuestMiddleware, authHandler.ResetPassword)
+	authGroup.Get("/accept-invite/:token", middlewares.GuestMiddleware, authHandler.ShowAcceptInvite)
+	authGroup.Post("/accept-invite/:token", middlewares.GuestMiddleware, authHandler.AcceptInvite)
 
 	authGroup.Get("/logout", middlewares.AuthMiddleware, authHandler.Logout)
 	authGroup.Get("/profile", middlewares.AuthMiddleware, authHandler.Profile)
 	authGroup.Post("/profile/update-password", middlewares.AuthMiddleware, authHandler.UpdatePassword)
+	authGroup.Post("/profile/update", middlewares.AuthMiddleware, authHandler.UpdateProfile)
+	authGroup.Post("/profile/avatar", middlewares.AuthMiddleware, authHandler.UploadAvatar)
+	authGroup.Post("/profile/avatar/delete", middlewares.AuthMiddleware, authHandler.DeleteAvatar)
+	authGroup.Get("/change-password", middlewares.AuthMiddleware, authHandler.ShowChangePassword)
+	authGroup.Post("/change-password", middlewares.AuthMiddleware, authHandler.ChangePassword)
+	authGroup.Post("/sessions/revoke-others", middlewares.AuthMiddleware, authHandler.RevokeOtherSessions)
+	authGroup.Post("/sessions/revoke/:id", middlewares.AuthMiddleware, authHandler.RevokeSession)
+	authGroup.Post("/remember/forget-all", middlewares.AuthMiddleware, authHandler.ForgetRememberedDevices)
+	authGroup.Get("/2fa/setup", middlewares.AuthMiddleware, authHandler.ShowTwoFactorSetup)
+	authGroup.Post("/2fa/setup", middlewares.AuthMiddleware, authHandler.ConfirmTwoFactorSetup)
+	authGroup.Post("/2fa/disable", middlewares.AuthMiddleware, authHandler.DisableTwoFactor)
+	authGroup.Post("/2fa/recovery-codes", middlewares.AuthMiddleware, authHandler.RegenerateRecoveryCodes)
 }
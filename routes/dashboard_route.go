@@ -13,18 +13,69 @@ func registerDashboardRoutes(app *fiber.App) {
 	dashboardGroup.Use(
 		middlewares.AuthMiddleware,
 		middlewares.StatusMiddleware,
-		middlewares.TypeMiddleware(models.System),
+		middlewares.RequirePermission(models.PermDashboardAccess),
+		middlewares.PasswordChangeMiddleware,
+		middlewares.TwoFactorSetupMiddleware,
 	)
 
 	homeHandler := handlers.NewHomeHandler()
 	dashboardGroup.Get("/home", homeHandler.HomePage)
 
+	canViewUsers := middlewares.RequirePermission(models.PermUsersView)
+	canCreateUsers := middlewares.RequirePermission(models.PermUsersCreate)
+	canUpdateUsers := middlewares.RequirePermission(models.PermUsersUpdate)
+	canDeleteUsers := middlewares.RequirePermission(models.PermUsersDelete)
+	canManageUserSecurity := middlewares.RequirePermission(models.PermUsersSecurity)
+
 	userHandler := handlers.NewUserHandler()
-	dashboardGroup.Get("/users", userHandler.ListUsers)
-	dashboardGroup.Get("/users/create", userHandler.ShowCreateUser)
-	dashboardGroup.Post("/users/create", userHandler.CreateUser)
-	dashboardGroup.Get("/users/update/:id", userHandler.ShowUpdateUser)
-	dashboardGroup.Post("/users/update/:id", userHandler.UpdateUser)
-	dashboardGroup.Post("/users/delete/:id", userHandler.DeleteUser)
-	dashboardGroup.Delete("/users/delete/:id", userHandler.DeleteUser)
+	dashboardGroup.Get("/users", canViewUsers, userHandler.ListUsers)
+	dashboardGroup.Get("/users/create", canCreateUsers, userHandler.ShowCreateUser)
+	dashboardGroup.Post("/users/create", canCreateUsers, userHandler.CreateUser)
+	dashboardGroup.Get("/users/export", canViewUsers, userHandler.ExportUsers)
+	dashboardGroup.Get("/users/import", canCreateUsers, userHandler.ShowImportUsers)
+	dashboardGroup.Get("/users/import/template", canCreateUsers, userHandler.DownloadImportTemplate)
+	dashboardGroup.Post("/users/import/preview", canCreateUsers, userHandler.PreviewImportUsers)
+	dashboardGroup.Post("/users/import/commit", canCreateUsers, userHandler.CommitImportUsers)
+	dashboardGroup.Get("/users/update/:id", canViewUsers, userHandler.ShowUpdateUser)
+	dashboardGroup.Post("/users/update/:id", canUpdateUsers, userHandler.UpdateUser)
+	dashboardGroup.Post("/users/delete/:id", canDeleteUsers, userHandler.DeleteUser)
+	dashboardGroup.Delete("/users/delete/:id", canDeleteUsers, userHandler.DeleteUser)
+	dashboardGroup.Post("/users/bulk/status", canUpdateUsers, userHandler.BulkSetStatus)
+	dashboardGroup.Post("/users/bulk/type", canUpdateUsers, userHandler.BulkSetType)
+	dashboardGroup.Post("/users/bulk/delete", canDeleteUsers, userHandler.BulkDelete)
+	dashboardGroup.Get("/users/trash", canDeleteUsers, userHandler.ListDeletedUsers)
+	dashboardGroup.Post("/users/restore/:id", canDeleteUsers, userHandler.RestoreUser)
+	dashboardGroup.Post("/users/purge/:id", canDeleteUsers, userHandler.PurgeUser)
+	dashboardGroup.Post("/users/unlock/:id", canManageUserSecurity, userHandler.UnlockUser)
+	dashboardGroup.Post("/users/reset-2fa/:id", canManageUserSecurity, userHandler.ResetTwoFactor)
+	dashboardGroup.Post("/users/logout-everywhere/:id", canManageUserSecurity, userHandler.LogoutEverywhere)
+	dashboardGroup.Post("/users/invitation/resend/:id", canCreateUsers, userHandler.ResendInvitation)
+	dashboardGroup.Post("/users/invitation/revoke/:id", canCreateUsers, userHandler.RevokeInvitation)
+	dashboardGroup.Post("/users/sessions/revoke/:id/:sessionId", canManageUserSecurity, userHandler.RevokeUserSession)
+
+	canManagePolicies := middlewares.RequirePermission(models.PermSecurityPolicies)
+	securityPolicyHandler := handlers.NewSecurityPolicyHandler()
+	dashboardGroup.Get("/security-policies", canManagePolicies, securityPolicyHandler.ShowPolicies)
+	dashboardGroup.Post("/security-policies", canManagePolicies, securityPolicyHandler.UpdatePolicies)
+
+	securityEventHandler := handlers.NewSecurityEventHandler()
+	dashboardGroup.Get("/security-events", middlewares.RequirePermission(models.PermSecurityEvents), securityEventHandler.ListEvents)
+
+	canManageTeams := middlewares.RequirePermission(models.PermTeamsManage)
+	teamHandler := handlers.NewTeamHandler()
+	dashboardGroup.Get("/teams", canManageTeams, teamHandler.ListTeams)
+	dashboardGroup.Get("/teams/create", canManageTeams, teamHandler.ShowCreateTeam)
+	dashboardGroup.Post("/teams/create", canManageTeams, teamHandler.CreateTeam)
+	dashboardGroup.Get("/teams/update/:id", canManageTeams, teamHandler.ShowUpdateTeam)
+	dashboardGroup.Post("/teams/update/:id", canManageTeams, teamHandler.UpdateTeam)
+	dashboardGroup.Post("/teams/delete/:id", canManageTeams, teamHandler.DeleteTeam)
+
+	canManageRoles := middlewares.RequirePermission(models.PermRolesManage)
+	roleHandler := handlers.NewRoleHandler()
+	dashboardGroup.Get("/roles", canManageRoles, roleHandler.ListRoles)
+	dashboardGroup.Get("/roles/create", canManageRoles, roleHandler.ShowCreateRole)
+	dashboardGroup.Post("/roles/create", canManageRoles, roleHandler.CreateRole)
+	dashboardGroup.Get("/roles/update/:id", canManageRoles, roleHandler.ShowUpdateRole)
+	dashboardGroup.Post("/roles/update/:id", canManageRoles, roleHandler.UpdateRole)
+	dashboardGroup.Post("/roles/delete/:id", canManageRoles, roleHandler.DeleteRole)
 }
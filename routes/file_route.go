@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"zatrano/configs"
+	handlers "zatrano/handlers/file"
+	"zatrano/middlewares"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func registerFileRoutes(app *fiber.App) {
+	fileHandler := handlers.NewFileHandler()
+
+	// İmzalı adresler oturum gerektirmez; genel indirme yolundan önce kaydedilmelidir.
+	app.Get(configs.SignedFilesPath+"/*", fileHandler.SignedDownload)
+	app.Get("/files/*", middlewares.AuthMiddleware, middlewares.StatusMiddleware, fileHandler.Download)
+}
@@ -13,7 +13,9 @@ func registerPanelRoutes(app *fiber.App) {
 	panelGroup.Use(
 		middlewares.AuthMiddleware,
 		middlewares.StatusMiddleware,
-		middlewares.TypeMiddleware(models.Panel),
+		middlewares.RequirePermission(models.PermPanelAccess),
+		middlewares.PasswordChangeMiddleware,
+		middlewares.TwoFactorSetupMiddleware,
 	)
 
 	panelGroup.Get("/home", handlers.PanelHomeHandler)
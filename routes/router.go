@@ -2,6 +2,7 @@ package routes
 
 import (
 	"zatrano/configs"
+	"zatrano/middlewares"
 	"zatrano/models"
 	"zatrano/utils"
 
@@ -18,10 +19,12 @@ func SetupRoutes(app *fiber.App, db *gorm.DB) {
 		c.Locals("session", sessionStore)
 		return c.Next()
 	})
+	app.Use(middlewares.RememberMeMiddleware)
 
 	registerAuthRoutes(app)
 	registerDashboardRoutes(app)
 	registerPanelRoutes(app)
+	registerFileRoutes(app)
 
 	app.Use(rootRedirector)
 }
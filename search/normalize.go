@@ -0,0 +1,25 @@
+// Package search, Türkçe harf ve aksan farklarını yok sayan arama normalizasyonunu ve pg_trgm ifadelerini sağlar.
+package search
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// turkishDotless, i harflerini küçük harfe çevirmeden önce birleştirir; aksi halde "İ" "i̇" olur.
+var turkishDotless = strings.NewReplacer("İ", "i", "I", "i", "ı", "i")
+
+var stripMarks = transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+
+// Normalize, i harflerini birleştirir, aksanları kaldırır, küçük harfe çevirir ve fazla boşlukları temizler.
+func Normalize(value string) string {
+	value = turkishDotless.Replace(value)
+	if stripped, _, err := transform.String(stripMarks, value); err == nil {
+		value = stripped
+	}
+	return strings.Join(strings.Fields(strings.ToLower(value)), " ")
+}
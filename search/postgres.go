@@ -0,0 +1,67 @@
+package search
+
+import (
+	"strings"
+
+	"gorm.io/gorm/clause"
+)
+
+// NormalizeFunc, Normalize ile aynı kuralları veritabanında uygulayan ve ifade indekslerinde kullanılabilen SQL fonksiyonudur.
+const NormalizeFunc = "search_normalize"
+
+// InstallSQL, arama eklentilerini ve normalizasyon fonksiyonunu oluşturur; IMMUTABLE kalması için unaccent sözlüğü açıkça verilir.
+var InstallSQL = []string{
+	`CREATE EXTENSION IF NOT EXISTS pg_trgm`,
+	`CREATE EXTENSION IF NOT EXISTS unaccent`,
+	`CREATE OR REPLACE FUNCTION ` + NormalizeFunc + `(value text) RETURNS text
+		LANGUAGE sql IMMUTABLE STRICT PARALLEL SAFE
+		AS $$ SELECT btrim(regexp_replace(lower(public.unaccent('public.unaccent'::regdictionary, translate(value, 'İIı', 'iii'))), '\s+', ' ', 'g')) $$`,
+}
+
+// TrigramIndexSQL, sütunun normalize edilmiş değeri üzerinde LIKE ve benzerlik aramalarını karşılayan GIN indeksini oluşturur.
+func TrigramIndexSQL(table, column string) string {
+	return "CREATE INDEX IF NOT EXISTS idx_" + table + "_" + column + "_trgm ON " + table +
+		" USING gin (" + normalized(column) + " gin_trgm_ops)"
+}
+
+// Contains, sütunun normalize edilmiş değerinde terimin geçtiği satırları seçen koşuldur.
+func Contains(column, term string) clause.Expr {
+	return clause.Expr{SQL: normalized(column) + " LIKE ?", Vars: []interface{}{likePattern(term)}}
+}
+
+// Matches, terimi sütunlardan birinde içeren ya da kelime benzerliği eşiği aşan satırları seçer.
+func Matches(term string, columns ...string) clause.Expr {
+	normalizedTerm := Normalize(term)
+	pattern := likePattern(term)
+
+	conditions := make([]string, 0, len(columns)*2)
+	vars := make([]interface{}, 0, len(columns)*2)
+	for _, column := range columns {
+		conditions = append(conditions, normalized(column)+" LIKE ?", "? <% "+normalized(column))
+		vars = append(vars, pattern, normalizedTerm)
+	}
+	return clause.Expr{SQL: "(" + strings.Join(conditions, " OR ") + ")", Vars: vars}
+}
+
+// Rank, terime en çok benzeyen sütunun kelime benzerliğini (0-1) veren ORDER BY ifadesidir.
+func Rank(term string, columns ...string) clause.Expr {
+	normalizedTerm := Normalize(term)
+
+	scores := make([]string, 0, len(columns))
+	vars := make([]interface{}, 0, len(columns))
+	for _, column := range columns {
+		scores = append(scores, "word_similarity(?, "+normalized(column)+")")
+		vars = append(vars, normalizedTerm)
+	}
+	return clause.Expr{SQL: "GREATEST(" + strings.Join(scores, ", ") + ")", Vars: vars, WithoutParentheses: true}
+}
+
+func normalized(column string) string {
+	return NormalizeFunc + "(" + column + ")"
+}
+
+// likePattern, normalize edilmiş terimi LIKE joker karakterlerinden kaçırarak "%terim%" kalıbına çevirir.
+func likePattern(term string) string {
+	escaped := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(Normalize(term))
+	return "%" + escaped + "%"
+}
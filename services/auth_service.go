@@ -1,6 +1,9 @@
 package services
 
 import (
+	"time"
+
+	"zatrano/configs"
 	"zatrano/models"
 	"zatrano/repositories"
 	"zatrano/utils"
@@ -20,35 +23,85 @@ const (
 	ErrInvalidCredentials       ServiceError = "geçersiz kimlik bilgileri"
 	ErrUserNotFound             ServiceError = "kullanıcı bulunamadı"
 	ErrUserInactive             ServiceError = "kullanıcı aktif değil"
+	ErrUserPending              ServiceError = "kullanıcı daveti henüz kabul etmedi"
 	ErrCurrentPasswordIncorrect ServiceError = "mevcut şifre hatalı"
-	ErrPasswordTooShort         ServiceError = "yeni şifre en az 6 karakter olmalıdır"
 	ErrPasswordSameAsOld        ServiceError = "yeni şifre mevcut şifre ile aynı olamaz"
 	ErrAuthGeneric              ServiceError = "kimlik doğrulaması sırasında bir hata oluştu"
 	ErrProfileGeneric           ServiceError = "profil bilgileri alınırken hata"
 	ErrUpdatePasswordGeneric    ServiceError = "şifre güncellenirken bir hata oluştu"
 	ErrHashingFailed            ServiceError = "yeni şifre oluşturulurken hata"
 	ErrDatabaseUpdateFailed     ServiceError = "veritabanı güncellemesi başarısız oldu"
+	ErrAccountLocked            ServiceError = "hesap çok sayıda başarısız giriş denemesi nedeniyle geçici olarak kilitlendi"
+	ErrTooManyLoginAttempts     ServiceError = "bu IP adresinden çok sayıda başarısız giriş denemesi yapıldı"
 )
 
+// LoginLockedError, IP adresi engelliyken döner; handler kalan süreyi Until üzerinden hesaplar. Hesap kilidi
+// ise hesabın var olup olmadığını belli etmemek için süresiz ErrAccountLocked ile bildirilir.
+type LoginLockedError struct {
+	Until time.Time
+}
+
+func (e *LoginLockedError) Error() string {
+	return string(ErrTooManyLoginAttempts)
+}
+
+func (e *LoginLockedError) Is(target error) bool {
+	return target == ErrTooManyLoginAttempts
+}
+
+// dummyPasswordHash, var olmayan hesaplarda da parola karşılaştırması yapılarak yanıt süresinin hesabın
+// varlığını ele vermemesi için kullanılır.
+var dummyPasswordHash, _ = bcrypt.GenerateFromPassword([]byte("zatrano-dummy-password"), bcrypt.DefaultCost)
+
 type IAuthService interface {
-	Authenticate(account, password string) (*models.User, error)
+	// Authenticate, hesap bulunduğunda hatalı parola, kilit, pasiflik ve davet hatalarında da kullanıcıyı döner;
+	// böylece başarısız denemeler hesabın giriş geçmişine işlenebilir.
+	Authenticate(account, password, ip string) (*models.User, error)
+	// VerifyTwoFactor, parolası doğrulanmış kullanıcının ikinci adım kodunu denetler. Hatalı kodlar parola
+	// hataları gibi hesap ve IP sayaçlarına işlenir; hesap sayacı yalnızca ikinci adım da geçildiğinde sıfırlanır.
+	VerifyTwoFactor(userID uint, code, ip string) (*models.User, error)
 	GetUserProfile(id uint) (*models.User, error)
 	UpdatePassword(userID uint, currentPass, newPassword string) error
 }
 
 type AuthService struct {
-	repo repositories.IAuthRepository
+	repo         repositories.IAuthRepository
+	attemptRepo  repositories.ILoginAttemptRepository
+	rememberRepo repositories.IRememberTokenRepository
+	passwords    IPasswordPolicyService
+	twoFactor    ITwoFactorService
+	lockout      configs.LoginLockoutConfig
 }
 
 func NewAuthService() IAuthService {
-	return &AuthService{repo: repositories.NewAuthRepository()}
+	return &AuthService{
+		repo:         repositories.NewAuthRepository(),
+		attemptRepo:  repositories.NewLoginAttemptRepository(),
+		rememberRepo: repositories.NewRememberTokenRepository(),
+		passwords:    NewPasswordPolicyService(),
+		twoFactor:    NewTwoFactorService(),
+		lockout:      configs.GetLoginLockoutConfig(),
+	}
 }
 
-func (s *AuthService) Authenticate(account, password string) (*models.User, error) {
+func (s *AuthService) Authenticate(account, password, ip string) (*models.User, error) {
+	if until, locked := s.ipLockedUntil(ip); locked {
+		utils.Log.Warn("Kimlik doğrulama reddedildi: IP adresi geçici olarak engelli",
+			zap.String("account", account),
+			zap.String("ip", ip),
+			zap.Time("locked_until", until),
+		)
+		return nil, &LoginLockedError{Until: until}
+	}
+
 	user, err := s.repo.FindUserByAccount(account)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			utils.Log.Warn("Kimlik doğrulama başarısız: Kullanıcı bulunamadı", zap.String("account", account))
+			_ = bcrypt.CompareHashAndPassword(dummyPasswordHash, []byte(password))
+			if until, locked := s.registerIPFailure(ip); locked {
+				return nil, &LoginLockedError{Until: until}
+			}
 			return nil, ErrInvalidCredentials
 		}
 		utils.Log.Error("Kimlik doğrulama hatası (DB)",
@@ -58,23 +111,53 @@ func (s *AuthService) Authenticate(account, password string) (*models.User, erro
 		return nil, ErrAuthGeneric
 	}
 
+	// Parola her durumda önce karşılaştırılır; kilit, pasiflik ve davet durumu hesabın varlığını ancak
+	// doğru parolayla belli eder. Kilitli hesapta parolanın doğru olup olmadığı da söylenmez.
+	passwordErr := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password))
+
+	if user.IsLocked() {
+		utils.Log.Warn("Kimlik doğrulama reddedildi: Hesap kilitli",
+			zap.String("account", account),
+			zap.Uint("user_id", user.ID),
+			zap.Time("locked_until", *user.LockedUntil),
+		)
+		return user, ErrAccountLocked
+	}
+
+	if passwordErr != nil {
+		utils.Log.Warn("Kimlik doğrulama başarısız: Geçersiz parola",
+			zap.String("account", account),
+			zap.Uint("user_id", user.ID),
+		)
+		return user, s.registerFailure(user, ip)
+	}
+
 	if !user.Status {
 		utils.Log.Warn("Kimlik doğrulama başarısız: Kullanıcı aktif değil",
 			zap.String("account", account),
 			zap.Uint("user_id", user.ID),
 		)
-		return nil, ErrUserInactive
+		return user, ErrUserInactive
 	}
 
-	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password))
-	if err != nil {
-		utils.Log.Warn("Kimlik doğrulama başarısız: Geçersiz parola",
+	if user.Pending {
+		utils.Log.Warn("Kimlik doğrulama başarısız: Davet henüz kabul edilmedi",
+			zap.String("account", account),
+			zap.Uint("user_id", user.ID),
+		)
+		return user, ErrUserPending
+	}
+
+	if user.TwoFactorEnabled {
+		utils.Log.Info("Parola doğrulandı, ikinci adım bekleniyor",
 			zap.String("account", account),
 			zap.Uint("user_id", user.ID),
 		)
-		return nil, ErrInvalidCredentials
+		return user, nil
 	}
 
+	s.resetLoginFailures(user)
+
 	utils.Log.Info("Kimlik doğrulama başarılı",
 		zap.String("account", account),
 		zap.Uint("user_id", user.ID),
@@ -82,6 +165,140 @@ func (s *AuthService) Authenticate(account, password string) (*models.User, erro
 	return user, nil
 }
 
+func (s *AuthService) VerifyTwoFactor(userID uint, code, ip string) (*models.User, error) {
+	if until, locked := s.ipLockedUntil(ip); locked {
+		utils.Log.Warn("2FA doğrulaması reddedildi: IP adresi geçici olarak engelli", zap.Uint("user_id", userID), zap.String("ip", ip))
+		return nil, &LoginLockedError{Until: until}
+	}
+
+	user, err := s.repo.FindUserByID(userID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, ErrUserNotFound
+		}
+		utils.Log.Error("2FA doğrulaması: Kullanıcı okunamadı", zap.Uint("user_id", userID), zap.Error(err))
+		return nil, ErrAuthGeneric
+	}
+	if user.IsLocked() {
+		utils.Log.Warn("2FA doğrulaması reddedildi: Hesap kilitli", zap.Uint("user_id", userID))
+		return nil, ErrAccountLocked
+	}
+	if !user.Status || user.Pending {
+		return nil, ErrUserInactive
+	}
+
+	if err := s.twoFactor.VerifyCode(userID, code); err != nil {
+		if err != ErrTwoFactorInvalidCode {
+			return nil, err
+		}
+		if failErr := s.registerFailure(user, ip); failErr != ErrInvalidCredentials {
+			return nil, failErr
+		}
+		return nil, ErrTwoFactorInvalidCode
+	}
+
+	s.resetLoginFailures(user)
+
+	utils.Log.Info("Kimlik doğrulama başarılı (iki adımlı)", zap.Uint("user_id", user.ID))
+	return user, nil
+}
+
+func (s *AuthService) ipLockedUntil(ip string) (time.Time, bool) {
+	attempt, err := s.attemptRepo.FindByIP(ip)
+	if err != nil {
+		if err != gorm.ErrRecordNotFound {
+			utils.Log.Error("IP giriş denemeleri okunamadı", zap.String("ip", ip), zap.Error(err))
+		}
+		return time.Time{}, false
+	}
+	if !attempt.IsLocked() {
+		return time.Time{}, false
+	}
+	return *attempt.LockedUntil, true
+}
+
+// registerFailure, hatalı parola veya ikinci adım kodunu hem hesabın hem de IP'nin sayacına işler ve
+// kullanıcıya dönülecek hatayı belirler.
+func (s *AuthService) registerFailure(user *models.User, ip string) error {
+	ipUntil, ipLocked := s.registerIPFailure(ip)
+	if s.registerAccountFailure(user) {
+		return ErrAccountLocked
+	}
+	if ipLocked {
+		return &LoginLockedError{Until: ipUntil}
+	}
+	return ErrInvalidCredentials
+}
+
+func (s *AuthService) registerIPFailure(ip string) (time.Time, bool) {
+	now := time.Now().UTC()
+	attempt, err := s.attemptRepo.RegisterFailure(ip, now, now.Add(-s.lockout.AttemptWindow))
+	if err != nil {
+		utils.Log.Error("IP giriş denemesi kaydedilemedi", zap.String("ip", ip), zap.Error(err))
+		return time.Time{}, false
+	}
+
+	lockDuration := s.lockout.LockDuration(attempt.FailedCount, s.lockout.IPMaxAttempts)
+	if lockDuration <= 0 {
+		return time.Time{}, false
+	}
+	until := now.Add(lockDuration)
+	if err := s.attemptRepo.Lock(ip, until); err != nil {
+		utils.Log.Error("IP adresi engellenemedi", zap.String("ip", ip), zap.Error(err))
+		return time.Time{}, false
+	}
+
+	utils.Log.Warn("IP adresi başarısız giriş denemeleri nedeniyle engellendi",
+		zap.String("ip", ip),
+		zap.Int("failed_count", attempt.FailedCount),
+		zap.Duration("lock_duration", lockDuration),
+	)
+	return until, true
+}
+
+func (s *AuthService) registerAccountFailure(user *models.User) bool {
+	now := time.Now().UTC()
+	failures, err := s.repo.RegisterLoginFailure(user.ID, now, now.Add(-s.lockout.AttemptWindow))
+	if err != nil {
+		utils.Log.Error("Başarısız giriş denemesi kaydedilemedi",
+			zap.Uint("user_id", user.ID),
+			zap.Error(err),
+		)
+		return false
+	}
+
+	lockDuration := s.lockout.LockDuration(failures, s.lockout.MaxAttempts)
+	if lockDuration <= 0 {
+		return false
+	}
+	if err := s.repo.UpdateUserFields(user.ID, map[string]interface{}{"locked_until": now.Add(lockDuration)}); err != nil {
+		utils.Log.Error("Hesap kilitlenemedi", zap.Uint("user_id", user.ID), zap.Error(err))
+		return false
+	}
+
+	utils.Log.Warn("Hesap başarısız giriş denemeleri nedeniyle kilitlendi",
+		zap.Uint("user_id", user.ID),
+		zap.Int("failed_attempts", failures),
+		zap.Duration("lock_duration", lockDuration),
+	)
+	return true
+}
+
+// resetLoginFailures, başarılı girişte hesabın sayacını sıfırlar. IP sayacı, geçerli bir hesapla araya giriş
+// sıkıştırılarak başka hesaplara deneme yapılamasın diye sıfırlanmaz; pencere dolunca kendiliğinden yenilenir.
+func (s *AuthService) resetLoginFailures(user *models.User) {
+	if user.FailedLoginAttempts > 0 || user.LockedUntil != nil || user.LastFailedLoginAt != nil {
+		err := s.repo.UpdateUserFields(user.ID, map[string]interface{}{
+			"failed_login_attempts": 0,
+			"last_failed_login_at":  nil,
+			"locked_until":          nil,
+		})
+		if err != nil {
+			utils.Log.Error("Başarısız giriş sayacı sıfırlanamadı", zap.Uint("user_id", user.ID), zap.Error(err))
+		}
+	}
+}
+
 func (s *AuthService) GetUserProfile(id uint) (*models.User, error) {
 	user, err := s.repo.FindUserByID(id)
 	if err != nil {
@@ -117,17 +334,17 @@ func (s *AuthService) UpdatePassword(userID uint, currentPass, newPassword strin
 		return ErrCurrentPasswordIncorrect
 	}
 
-	if len(newPassword) < 6 {
-		utils.Log.Warn("Parola güncelleme başarısız: Yeni parola çok kısa", zap.Uint("user_id", userID))
-		return ErrPasswordTooShort
-	}
 	if currentPass == newPassword {
 		utils.Log.Warn("Parola güncelleme başarısız: Yeni parola eskiyle aynı", zap.Uint("user_id", userID))
 		return ErrPasswordSameAsOld
 	}
 
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
-	if err != nil {
+	if err := s.passwords.Validate(user, newPassword); err != nil {
+		utils.Log.Warn("Parola güncelleme başarısız: Şifre politikasına uymuyor", zap.Uint("user_id", userID))
+		return err
+	}
+
+	if err := user.SetPassword(newPassword); err != nil {
 		utils.Log.Error("Parola güncelleme hatası: Yeni parola hashlenemedi",
 			zap.Uint("user_id", userID),
 			zap.Error(err),
@@ -135,7 +352,10 @@ func (s *AuthService) UpdatePassword(userID uint, currentPass, newPassword strin
 		return ErrHashingFailed
 	}
 
-	user.Password = string(hashedPassword)
+	now := time.Now().UTC()
+	user.CredentialsChangedAt = &now
+	user.MustChangePassword = false
+	user.SessionVersion++
 	if err := s.repo.UpdateUser(user); err != nil {
 		utils.Log.Error("Parola güncelleme hatası: Kullanıcı güncellenirken DB hatası",
 			zap.Uint("user_id", userID),
@@ -144,6 +364,12 @@ func (s *AuthService) UpdatePassword(userID uint, currentPass, newPassword strin
 		return ErrDatabaseUpdateFailed
 	}
 
+	s.passwords.RecordPassword(userID, user.Type, user.Password)
+
+	if _, err := s.rememberRepo.DeleteByUser(userID); err != nil {
+		utils.Log.Warn("Parola güncellendi ancak hatırlama anahtarları silinemedi", zap.Uint("user_id", userID), zap.Error(err))
+	}
+
 	utils.Log.Info("Parola başarıyla güncellendi", zap.Uint("user_id", userID))
 	return nil
 }
@@ -0,0 +1,151 @@
+package services
+
+import (
+	"strconv"
+	"strings"
+
+	"zatrano/configs"
+	"zatrano/models"
+	"zatrano/storage"
+	"zatrano/utils"
+
+	"go.uber.org/zap"
+)
+
+type FileServiceError string
+
+func (e FileServiceError) Error() string {
+	return string(e)
+}
+
+const (
+	ErrFileNotFound  FileServiceError = "dosya bulunamadı"
+	ErrFileForbidden FileServiceError = "bu dosyayı görüntüleme yetkiniz yok"
+	ErrFileLinkFail  FileServiceError = "dosya bağlantısı oluşturulamadı"
+)
+
+// fileAccessRule, depodaki bir anahtar önekinin hangi kullanıcılar tarafından indirilebileceğini belirler.
+// rest, önekten sonra kalan anahtar parçasıdır.
+type fileAccessRule struct {
+	prefix string
+	allow  func(s *FileService, viewerID uint, rest string) bool
+}
+
+// fileAccessRules'da eşleşmeyen anahtarlar kimseye sunulmaz; yeni dosya türleri buraya kural eklenerek açılır.
+var fileAccessRules = []fileAccessRule{
+	{prefix: "avatars/", allow: (*FileService).canViewUserFile},
+}
+
+type IFileService interface {
+	// Open, dosyayı görüntüleyenin yetkisini denetledikten sonra akış olarak açar.
+	Open(viewerID uint, key string) (*storage.Object, error)
+	// SignedURL, yetki denetiminden sonra dosya için süreli, oturum gerektirmeyen bir adres üretir.
+	SignedURL(viewerID uint, key string) (string, error)
+	// OpenSigned, uygulamanın kendisi tarafından imzalanan (yerel depo) bir adresi doğrular ve dosyayı açar.
+	OpenSigned(key string, expires int64, signature string) (*storage.Object, error)
+	// RedirectDownloads, indirmelerin uygulama üzerinden akıtılması yerine imzalı adrese yönlendirilip
+	// yönlendirilmeyeceğini belirtir.
+	RedirectDownloads() bool
+}
+
+type FileService struct {
+	files       storage.Storage
+	roleService IRoleService
+	users       IUserService
+}
+
+func NewFileService() IFileService {
+	return &FileService{
+		files:       configs.GetStorage(),
+		roleService: NewRoleService(),
+		users:       NewUserService(),
+	}
+}
+
+func (s *FileService) Open(viewerID uint, key string) (*storage.Object, error) {
+	cleaned, err := s.authorize(viewerID, key)
+	if err != nil {
+		return nil, err
+	}
+	return s.open(cleaned)
+}
+
+func (s *FileService) SignedURL(viewerID uint, key string) (string, error) {
+	cleaned, err := s.authorize(viewerID, key)
+	if err != nil {
+		return "", err
+	}
+	signed, err := s.files.SignedURL(cleaned, configs.FileURLTTL())
+	if err != nil {
+		utils.Log.Error("İmzalı dosya adresi üretilemedi", zap.String("key", cleaned), zap.Error(err))
+		return "", ErrFileLinkFail
+	}
+	return signed, nil
+}
+
+func (s *FileService) OpenSigned(key string, expires int64, signature string) (*storage.Object, error) {
+	verifier, ok := s.files.(storage.URLVerifier)
+	if !ok || !verifier.VerifySignedURL(key, expires, signature) {
+		return nil, ErrFileForbidden
+	}
+	return s.open(key)
+}
+
+func (s *FileService) RedirectDownloads() bool {
+	return configs.FileDownloadRedirect()
+}
+
+func (s *FileService) open(key string) (*storage.Object, error) {
+	object, err := s.files.Open(key)
+	if err != nil {
+		if err != storage.ErrNotFound && err != storage.ErrInvalidKey {
+			utils.Log.Error("Dosya depodan okunamadı", zap.String("key", key), zap.Error(err))
+		}
+		return nil, ErrFileNotFound
+	}
+	return object, nil
+}
+
+// authorize, anahtarı normalize eder ve görüntüleyenin dosyaya erişip erişemeyeceğini kurallara göre denetler.
+func (s *FileService) authorize(viewerID uint, key string) (string, error) {
+	cleaned, err := storage.CleanKey(key)
+	if err != nil {
+		return "", ErrFileNotFound
+	}
+	for _, rule := range fileAccessRules {
+		if rest, ok := strings.CutPrefix(cleaned, rule.prefix); ok {
+			if rule.allow(s, viewerID, rest) {
+				return cleaned, nil
+			}
+			break
+		}
+	}
+	utils.Log.Warn("Yetkisiz dosya erişimi engellendi", zap.Uint("viewer_id", viewerID), zap.String("key", cleaned))
+	return "", ErrFileForbidden
+}
+
+// canViewUserFile, "<kullanıcı id>/..." biçimindeki kullanıcıya ait dosyalara kullanıcının kendisinin ve
+// kullanıcıyı kapsamında görüntüleme yetkisi olanların erişmesine izin verir.
+func (s *FileService) canViewUserFile(viewerID uint, rest string) bool {
+	owner, _, found := strings.Cut(rest, "/")
+	if !found {
+		return false
+	}
+	ownerID, err := strconv.ParseUint(owner, 10, 64)
+	if err != nil {
+		return false
+	}
+	if uint(ownerID) == viewerID {
+		return true
+	}
+	if !s.roleService.HasPermission(viewerID, models.PermUsersView) {
+		return false
+	}
+	target, err := s.users.GetUserByID(uint(ownerID))
+	if err != nil {
+		return false
+	}
+	return s.users.CanManage(s.users.ResolveScope(viewerID), target)
+}
+
+var _ IFileService = (*FileService)(nil)
@@ -0,0 +1,96 @@
+package services
+
+import (
+	"zatrano/models"
+	"zatrano/passwordpolicy"
+	"zatrano/repositories"
+	"zatrano/utils"
+
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type IPasswordPolicyService interface {
+	PolicyFor(userType models.UserType) passwordpolicy.Policy
+	Validate(user *models.User, password string) error
+	RecordPassword(userID uint, userType models.UserType, passwordHash string)
+}
+
+type PasswordPolicyService struct {
+	policyService IUserTypePolicyService
+	historyRepo   repositories.IPasswordHistoryRepository
+}
+
+func NewPasswordPolicyService() IPasswordPolicyService {
+	return &PasswordPolicyService{
+		policyService: NewUserTypePolicyService(),
+		historyRepo:   repositories.NewPasswordHistoryRepository(),
+	}
+}
+
+// InitPasswordPolicy, models.User.SetPassword'ün kullanıcı tipine ait kayıtlı politikayı kullanmasını sağlar.
+func InitPasswordPolicy() {
+	passwordpolicy.SetResolver(func(userType string) passwordpolicy.Policy {
+		policy := NewUserTypePolicyService().GetPolicy(models.UserType(userType))
+		return policy.PasswordPolicy()
+	})
+	utils.SLog.Info("Şifre politikası kullanıcı tipi ayarlarından okunacak şekilde yapılandırıldı.")
+}
+
+func (s *PasswordPolicyService) PolicyFor(userType models.UserType) passwordpolicy.Policy {
+	policy := s.policyService.GetPolicy(userType)
+	return policy.PasswordPolicy()
+}
+
+// Validate, parolayı kullanıcının tipine ve hesap adına göre doğrular. user.ID doluysa
+// mevcut parola ve son N parola ile tekrar kullanım da kontrol edilir.
+func (s *PasswordPolicyService) Validate(user *models.User, password string) error {
+	policy := s.PolicyFor(user.Type)
+	violations := policy.Check(password, user.Account)
+
+	if password != "" && user.ID != 0 && policy.HistoryCount > 0 && s.isReused(user, password, policy.HistoryCount) {
+		violations = append(violations, policy.HistoryViolation())
+	}
+
+	return passwordpolicy.NewError(violations)
+}
+
+func (s *PasswordPolicyService) isReused(user *models.User, password string, historyCount int) bool {
+	hashes := make([]string, 0, historyCount+1)
+	if user.Password != "" {
+		hashes = append(hashes, user.Password)
+	}
+
+	entries, err := s.historyRepo.FindRecentByUser(user.ID, historyCount)
+	if err != nil {
+		utils.Log.Warn("Şifre geçmişi okunamadı", zap.Uint("user_id", user.ID), zap.Error(err))
+	}
+	for _, entry := range entries {
+		hashes = append(hashes, entry.PasswordHash)
+	}
+
+	for _, hash := range hashes {
+		if bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// RecordPassword, yeni parola özetini geçmişe ekler ve politikanın gerektirdiğinden eski kayıtları siler.
+func (s *PasswordPolicyService) RecordPassword(userID uint, userType models.UserType, passwordHash string) {
+	if err := s.historyRepo.Create(&models.PasswordHistory{UserID: userID, PasswordHash: passwordHash}); err != nil {
+		utils.Log.Warn("Şifre geçmişine kayıt eklenemedi", zap.Uint("user_id", userID), zap.Error(err))
+		return
+	}
+
+	keep := s.PolicyFor(userType).HistoryCount
+	if keep < 1 {
+		keep = 1
+	}
+	if err := s.historyRepo.DeleteAllButRecent(userID, keep); err != nil {
+		utils.Log.Warn("Eski şifre geçmişi kayıtları silinemedi", zap.Uint("user_id", userID), zap.Error(err))
+	}
+}
+
+var _ IPasswordPolicyService = (*PasswordPolicyService)(nil)
@@ -0,0 +1,195 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"zatrano/configs"
+	"zatrano/mailer"
+	"zatrano/models"
+	"zatrano/repositories"
+	"zatrano/utils"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	ErrResetTokenInvalid ServiceError = "şifre sıfırlama bağlantısı geçersiz veya süresi dolmuş"
+	ErrResetGeneric      ServiceError = "şifre sıfırlanırken bir hata oluştu"
+)
+
+type IPasswordResetService interface {
+	RequestReset(account, ip string) error
+	ValidateToken(token string) error
+	ResetPassword(token, newPassword string) (uint, error)
+}
+
+type PasswordResetService struct {
+	repo      repositories.IPasswordResetRepository
+	authRepo  repositories.IAuthRepository
+	sessions  ISessionService
+	passwords IPasswordPolicyService
+	mailer    mailer.Mailer
+	ttl       time.Duration
+	appURL    string
+}
+
+func NewPasswordResetService() IPasswordResetService {
+	return &PasswordResetService{
+		repo:      repositories.NewPasswordResetRepository(),
+		authRepo:  repositories.NewAuthRepository(),
+		sessions:  NewSessionService(),
+		passwords: NewPasswordPolicyService(),
+		mailer:    configs.GetMailer(),
+		ttl:       configs.GetPasswordResetTTL(),
+		appURL:    configs.GetAppURL(),
+	}
+}
+
+// RequestReset, hesabın var olup olmadığını dışarıya sızdırmamak için bilinmeyen hesaplarda da nil döner.
+func (s *PasswordResetService) RequestReset(account, ip string) error {
+	user, err := s.authRepo.FindUserByAccount(account)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			utils.Log.Info("Şifre sıfırlama isteği: Hesap bulunamadı", zap.String("account", account), zap.String("ip", ip))
+			return nil
+		}
+		utils.Log.Error("Şifre sıfırlama isteği: Kullanıcı aranırken hata", zap.String("account", account), zap.Error(err))
+		return ErrResetGeneric
+	}
+	if !user.Status {
+		utils.Log.Info("Şifre sıfırlama isteği: Hesap aktif değil", zap.Uint("user_id", user.ID))
+		return nil
+	}
+	if user.Pending {
+		utils.Log.Info("Şifre sıfırlama isteği: Davet henüz kabul edilmedi", zap.Uint("user_id", user.ID))
+		return nil
+	}
+	if user.Email == "" {
+		utils.Log.Warn("Şifre sıfırlama isteği: Kullanıcının e-posta adresi yok", zap.Uint("user_id", user.ID))
+		return nil
+	}
+
+	token, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		utils.Log.Error("Şifre sıfırlama tokenı üretilemedi", zap.Uint("user_id", user.ID), zap.Error(err))
+		return ErrResetGeneric
+	}
+
+	if err := s.repo.InvalidateForUser(user.ID); err != nil {
+		utils.Log.Error("Eski şifre sıfırlama tokenları geçersiz kılınamadı", zap.Uint("user_id", user.ID), zap.Error(err))
+		return ErrResetGeneric
+	}
+
+	now := time.Now().UTC()
+	if _, err := s.repo.DeleteExpired(now); err != nil {
+		utils.Log.Warn("Süresi dolmuş şifre sıfırlama tokenları temizlenemedi", zap.Error(err))
+	}
+
+	resetToken := &models.PasswordResetToken{
+		UserID:    user.ID,
+		TokenHash: utils.HashToken(token),
+		ExpiresAt: now.Add(s.ttl),
+		RequestIP: ip,
+	}
+	if err := s.repo.Create(resetToken); err != nil {
+		utils.Log.Error("Şifre sıfırlama tokenı kaydedilemedi", zap.Uint("user_id", user.ID), zap.Error(err))
+		return ErrResetGeneric
+	}
+
+	msg := mailer.Message{
+		To:      []string{user.Email},
+		Subject: "Şifre sıfırlama talebi",
+		TextBody: fmt.Sprintf("Merhaba %s,\n\n"+
+			"Hesabınız için bir şifre sıfırlama talebi aldık. Yeni şifrenizi belirlemek için aşağıdaki bağlantıyı kullanın:\n\n"+
+			"%s/auth/reset/%s\n\n"+
+			"Bu bağlantı %d dakika boyunca ve yalnızca bir kez geçerlidir. Talebi siz yapmadıysanız bu e-postayı dikkate almayın.\n",
+			user.Name, s.appURL, token, int(s.ttl.Minutes())),
+	}
+
+	go func(userID uint) {
+		if err := s.mailer.Send(msg); err != nil {
+			utils.Log.Error("Şifre sıfırlama e-postası gönderilemedi", zap.Uint("user_id", userID), zap.Error(err))
+		}
+	}(user.ID)
+
+	utils.Log.Info("Şifre sıfırlama bağlantısı oluşturuldu", zap.Uint("user_id", user.ID), zap.String("ip", ip))
+	return nil
+}
+
+func (s *PasswordResetService) ValidateToken(token string) error {
+	_, err := s.findUsableToken(token)
+	return err
+}
+
+func (s *PasswordResetService) ResetPassword(token, newPassword string) (uint, error) {
+	resetToken, err := s.findUsableToken(token)
+	if err != nil {
+		return 0, err
+	}
+
+	user, err := s.authRepo.FindUserByID(resetToken.UserID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return 0, ErrResetTokenInvalid
+		}
+		utils.Log.Error("Şifre sıfırlama: Kullanıcı okunamadı", zap.Uint("user_id", resetToken.UserID), zap.Error(err))
+		return 0, ErrResetGeneric
+	}
+
+	if err := s.passwords.Validate(user, newPassword); err != nil {
+		return 0, err
+	}
+
+	if err := user.SetPassword(newPassword); err != nil {
+		utils.Log.Error("Şifre sıfırlama: Yeni parola hashlenemedi", zap.Uint("user_id", resetToken.UserID), zap.Error(err))
+		return 0, ErrHashingFailed
+	}
+
+	claimed, err := s.repo.Redeem(resetToken.ID, resetToken.UserID, map[string]interface{}{
+		"password":               user.Password,
+		"credentials_changed_at": time.Now().UTC(),
+		"must_change_password":   false,
+		"session_version":        models.NextSessionVersion(),
+		"failed_login_attempts":  0,
+		"last_failed_login_at":   nil,
+		"locked_until":           nil,
+	})
+	if err != nil {
+		utils.Log.Error("Şifre sıfırlama: Kullanıcı güncellenemedi", zap.Uint("user_id", resetToken.UserID), zap.Error(err))
+		return 0, ErrResetGeneric
+	}
+	if !claimed {
+		return 0, ErrResetTokenInvalid
+	}
+
+	s.passwords.RecordPassword(user.ID, user.Type, user.Password)
+
+	if _, err := s.sessions.RevokeAll(resetToken.UserID); err != nil {
+		utils.Log.Warn("Şifre sıfırlama: Mevcut oturumlar sonlandırılamadı", zap.Uint("user_id", resetToken.UserID), zap.Error(err))
+	}
+
+	utils.Log.Info("Şifre sıfırlama tamamlandı", zap.Uint("user_id", resetToken.UserID))
+	return resetToken.UserID, nil
+}
+
+func (s *PasswordResetService) findUsableToken(token string) (*models.PasswordResetToken, error) {
+	if token == "" {
+		return nil, ErrResetTokenInvalid
+	}
+	resetToken, err := s.repo.FindByHash(utils.HashToken(token))
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, ErrResetTokenInvalid
+		}
+		utils.Log.Error("Şifre sıfırlama tokenı aranırken hata", zap.Error(err))
+		return nil, ErrResetGeneric
+	}
+	if !resetToken.IsUsable() {
+		return nil, ErrResetTokenInvalid
+	}
+	return resetToken, nil
+}
+
+var _ IPasswordResetService = (*PasswordResetService)(nil)
@@ -0,0 +1,209 @@
+package services
+
+import (
+	"strings"
+	"time"
+
+	"zatrano/configs"
+	"zatrano/models"
+	"zatrano/repositories"
+	"zatrano/utils"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	ErrRememberTokenInvalid ServiceError = "hatırlama anahtarı geçersiz"
+	ErrRememberTokenStale   ServiceError = "hatırlama anahtarı başka bir istekte yenilendi"
+	ErrRememberTokenReused  ServiceError = "hatırlama anahtarı yeniden kullanıldı"
+	ErrRememberTokenFailed  ServiceError = "hatırlama anahtarı işlenirken bir hata oluştu"
+)
+
+const (
+	rememberSelectorBytes  = 12
+	rememberValidatorBytes = 32
+	// Eşzamanlı isteklerin aynı çerezle gelmesi hırsızlık sayılmasın diye önceki validator kısa süre tanınır.
+	rememberRotationGrace = 30 * time.Second
+)
+
+// RememberedLogin, geçerli bir "beni hatırla" çerezi ile açılan girişin bilgilerini taşır.
+type RememberedLogin struct {
+	UserID      uint
+	User        *models.User
+	Selector    string
+	CookieValue string
+	ExpiresAt   time.Time
+}
+
+type IRememberMeService interface {
+	Issue(userID uint, ip, userAgent string) (*RememberedLogin, error)
+	Consume(cookieValue, ip, userAgent string) (*RememberedLogin, error)
+	Forget(cookieValue string) error
+	ForgetAll(userID uint) (int64, error)
+	CountActive(userID uint) int64
+}
+
+type RememberMeService struct {
+	repo     repositories.IRememberTokenRepository
+	authRepo repositories.IAuthRepository
+	lifetime time.Duration
+}
+
+func NewRememberMeService() IRememberMeService {
+	return &RememberMeService{
+		repo:     repositories.NewRememberTokenRepository(),
+		authRepo: repositories.NewAuthRepository(),
+		lifetime: configs.GetRememberMeDuration(),
+	}
+}
+
+func (s *RememberMeService) Issue(userID uint, ip, userAgent string) (*RememberedLogin, error) {
+	selector, err := utils.GenerateRandomToken(rememberSelectorBytes)
+	if err != nil {
+		utils.Log.Error("Hatırlama anahtarı üretilemedi", zap.Uint("user_id", userID), zap.Error(err))
+		return nil, ErrRememberTokenFailed
+	}
+	validator, err := utils.GenerateRandomToken(rememberValidatorBytes)
+	if err != nil {
+		utils.Log.Error("Hatırlama anahtarı üretilemedi", zap.Uint("user_id", userID), zap.Error(err))
+		return nil, ErrRememberTokenFailed
+	}
+
+	if len(userAgent) > 255 {
+		userAgent = userAgent[:255]
+	}
+
+	now := time.Now().UTC()
+	if _, err := s.repo.DeleteExpired(now); err != nil {
+		utils.Log.Warn("Süresi dolmuş hatırlama anahtarları temizlenemedi", zap.Error(err))
+	}
+
+	token := &models.RememberToken{
+		UserID:        userID,
+		Selector:      selector,
+		ValidatorHash: utils.HashToken(validator),
+		ExpiresAt:     now.Add(s.lifetime),
+		IP:            ip,
+		UserAgent:     userAgent,
+	}
+	if err := s.repo.Create(token); err != nil {
+		utils.Log.Error("Hatırlama anahtarı kaydedilemedi", zap.Uint("user_id", userID), zap.Error(err))
+		return nil, ErrRememberTokenFailed
+	}
+
+	return &RememberedLogin{
+		UserID:      userID,
+		Selector:    selector,
+		CookieValue: selector + ":" + validator,
+		ExpiresAt:   token.ExpiresAt,
+	}, nil
+}
+
+// Consume, çerezi doğrular ve validator'ı yeniler. Selector doğru ama validator eskiyse anahtar çalınmış
+// kabul edilir ve kullanıcının tüm hatırlama anahtarları silinir.
+func (s *RememberMeService) Consume(cookieValue, ip, userAgent string) (*RememberedLogin, error) {
+	selector, validator, ok := strings.Cut(cookieValue, ":")
+	if !ok || selector == "" || validator == "" {
+		return nil, ErrRememberTokenInvalid
+	}
+
+	token, err := s.repo.FindBySelector(selector)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, ErrRememberTokenInvalid
+		}
+		utils.Log.Error("Hatırlama anahtarı okunamadı", zap.Error(err))
+		return nil, ErrRememberTokenFailed
+	}
+
+	if token.IsExpired() {
+		_ = s.repo.DeleteBySelector(selector)
+		return nil, ErrRememberTokenInvalid
+	}
+
+	presentedHash := utils.HashToken(validator)
+	if !utils.TokenHashEquals(token.ValidatorHash, presentedHash) {
+		if token.PreviousValidatorHash != "" && token.RotatedAt != nil &&
+			time.Since(*token.RotatedAt) < rememberRotationGrace &&
+			utils.TokenHashEquals(token.PreviousValidatorHash, presentedHash) {
+			return nil, ErrRememberTokenStale
+		}
+
+		count, delErr := s.repo.DeleteByUser(token.UserID)
+		if delErr != nil {
+			utils.Log.Error("Çalınmış olabilecek hatırlama anahtarları silinemedi", zap.Uint("user_id", token.UserID), zap.Error(delErr))
+		}
+		utils.Log.Warn("Hatırlama anahtarı yeniden kullanıldı, kullanıcının tüm anahtarları iptal edildi",
+			zap.Uint("user_id", token.UserID),
+			zap.String("ip", ip),
+			zap.Int64("revoked", count),
+		)
+		return &RememberedLogin{UserID: token.UserID}, ErrRememberTokenReused
+	}
+
+	user, err := s.authRepo.FindUserByID(token.UserID)
+	if err != nil || !user.Status || user.IsLocked() {
+		_ = s.repo.DeleteBySelector(selector)
+		return nil, ErrRememberTokenInvalid
+	}
+
+	newValidator, err := utils.GenerateRandomToken(rememberValidatorBytes)
+	if err != nil {
+		utils.Log.Error("Hatırlama anahtarı yenilenemedi", zap.Uint("user_id", user.ID), zap.Error(err))
+		return nil, ErrRememberTokenFailed
+	}
+
+	if len(userAgent) > 255 {
+		userAgent = userAgent[:255]
+	}
+
+	rotated, err := s.repo.Rotate(token.ID, token.ValidatorHash, utils.HashToken(newValidator), ip, userAgent, time.Now().UTC())
+	if err != nil {
+		utils.Log.Error("Hatırlama anahtarı yenilenemedi", zap.Uint("user_id", user.ID), zap.Error(err))
+		return nil, ErrRememberTokenFailed
+	}
+	if !rotated {
+		return nil, ErrRememberTokenStale
+	}
+
+	return &RememberedLogin{
+		UserID:      user.ID,
+		User:        user,
+		Selector:    selector,
+		CookieValue: selector + ":" + newValidator,
+		ExpiresAt:   token.ExpiresAt,
+	}, nil
+}
+
+func (s *RememberMeService) Forget(cookieValue string) error {
+	selector, _, _ := strings.Cut(cookieValue, ":")
+	if selector == "" {
+		return nil
+	}
+	if err := s.repo.DeleteBySelector(selector); err != nil {
+		utils.Log.Warn("Hatırlama anahtarı silinemedi", zap.Error(err))
+		return ErrRememberTokenFailed
+	}
+	return nil
+}
+
+func (s *RememberMeService) ForgetAll(userID uint) (int64, error) {
+	count, err := s.repo.DeleteByUser(userID)
+	if err != nil {
+		utils.Log.Error("Kullanıcının hatırlama anahtarları silinemedi", zap.Uint("user_id", userID), zap.Error(err))
+		return 0, ErrRememberTokenFailed
+	}
+	return count, nil
+}
+
+func (s *RememberMeService) CountActive(userID uint) int64 {
+	count, err := s.repo.CountByUser(userID)
+	if err != nil {
+		utils.Log.Warn("Hatırlama anahtarı sayısı alınamadı", zap.Uint("user_id", userID), zap.Error(err))
+		return 0
+	}
+	return count
+}
+
+var _ IRememberMeService = (*RememberMeService)(nil)
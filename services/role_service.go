@@ -0,0 +1,298 @@
+package services
+
+import (
+	"regexp"
+	"strings"
+
+	"zatrano/models"
+	"zatrano/repositories"
+	"zatrano/utils"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	ErrRoleNotFound        ServiceError = "rol bulunamadı"
+	ErrRoleNameRequired    ServiceError = "rol adı boş olamaz"
+	ErrRoleSlugInvalid     ServiceError = "rol kısa adı yalnızca küçük harf, rakam, nokta, tire ve alt çizgi içerebilir"
+	ErrRoleBuiltInDelete   ServiceError = "yerleşik roller silinemez"
+	ErrRoleSaveFailed      ServiceError = "rol kaydedilemedi"
+	ErrRoleDeleteFailed    ServiceError = "rol silinemedi"
+	ErrRoleAssignFailed    ServiceError = "kullanıcı rolleri güncellenemedi"
+	ErrRoleAssignForbidden ServiceError = "kullanıcıların rollerini değiştirmek için rol yönetimi yetkisi gerekir"
+	ErrRoleGrantExceeds    ServiceError = "sahip olmadığınız izinleri içeren bir rol atanamaz"
+	ErrRoleEditExceeds     ServiceError = "sahip olmadığınız izinleri içeren bir rol düzenlenemez"
+	ErrPermissionLoadError ServiceError = "izinler okunamadı"
+)
+
+var roleSlugPattern = regexp.MustCompile(`^[a-z0-9._-]+$`)
+
+type IRoleService interface {
+	GetRoles() ([]models.Role, error)
+	GetRoleByID(id uint) (*models.Role, error)
+	GetPermissions() ([]models.Permission, error)
+	// CreateRole ve UpdateRole, işlemi yapanın sahip olmadığı izinleri role eklemesini reddeder.
+	CreateRole(actorID uint, role *models.Role, permissionKeys []string) error
+	UpdateRole(actorID, id uint, name, description string, permissionKeys []string) error
+	DeleteRole(id uint) error
+	CountRoleUsers(id uint) int64
+	GetUserPermissions(userID uint) (map[string]bool, error)
+	HasPermission(userID uint, permission string) bool
+	// AuthorizeRoleChange, actorID'nin kullanıcının rollerini current'tan requested'a (boşsa tipin yerleşik
+	// rolüne) değiştirip değiştiremeyeceğini denetler. explicit, rollerin elle seçildiğini belirtir ve rol
+	// yönetimi yetkisi ister; yeni verilen her rolün izinlerine işlemi yapanın zaten sahip olması gerekir.
+	AuthorizeRoleChange(actorID uint, userType models.UserType, current, requested []uint, explicit bool) error
+	GetDefaultRole(userType models.UserType) (*models.Role, error)
+	// ResolveRoles, verilen rolleri izinleriyle döner; hiç rol yoksa kullanıcı tipine karşılık gelen yerleşik rolü döner.
+	ResolveRoles(userType models.UserType, roleIDs []uint) ([]models.Role, error)
+}
+
+type RoleService struct {
+	repo repositories.IRoleRepository
+}
+
+func NewRoleService() IRoleService {
+	return &RoleService{repo: repositories.NewRoleRepository()}
+}
+
+func (s *RoleService) GetRoles() ([]models.Role, error) {
+	roles, err := s.repo.FindAll()
+	if err != nil {
+		utils.Log.Error("Roller alınamadı", zap.Error(err))
+		return nil, err
+	}
+	return roles, nil
+}
+
+func (s *RoleService) GetRoleByID(id uint) (*models.Role, error) {
+	role, err := s.repo.FindByID(id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, ErrRoleNotFound
+		}
+		utils.Log.Error("Rol alınamadı", zap.Uint("role_id", id), zap.Error(err))
+		return nil, err
+	}
+	return role, nil
+}
+
+func (s *RoleService) GetPermissions() ([]models.Permission, error) {
+	permissions, err := s.repo.FindAllPermissions()
+	if err != nil {
+		utils.Log.Error("İzinler alınamadı", zap.Error(err))
+		return nil, ErrPermissionLoadError
+	}
+	return permissions, nil
+}
+
+func (s *RoleService) CreateRole(actorID uint, role *models.Role, permissionKeys []string) error {
+	role.Name = strings.TrimSpace(role.Name)
+	role.Slug = strings.ToLower(strings.TrimSpace(role.Slug))
+	if role.Name == "" {
+		return ErrRoleNameRequired
+	}
+	if !roleSlugPattern.MatchString(role.Slug) {
+		return ErrRoleSlugInvalid
+	}
+	role.BuiltIn = false
+	if err := s.ensureActorHolds(actorID, permissionKeys, ErrRoleGrantExceeds); err != nil {
+		return err
+	}
+
+	permissions, err := s.repo.FindPermissionsByKeys(permissionKeys)
+	if err != nil {
+		utils.Log.Error("Rol oluşturma: İzinler okunamadı", zap.Error(err))
+		return ErrPermissionLoadError
+	}
+	role.Permissions = permissions
+
+	if err := s.repo.Create(role); err != nil {
+		utils.Log.Error("Rol oluşturulamadı", zap.String("slug", role.Slug), zap.Error(err))
+		return ErrRoleSaveFailed
+	}
+
+	utils.Log.Info("Rol oluşturuldu", zap.Uint("role_id", role.ID), zap.String("slug", role.Slug), zap.Int("permissions", len(permissions)))
+	return nil
+}
+
+// UpdateRole, rolün adını, açıklamasını ve izinlerini günceller. Sistem rolü her zaman tüm izinleri korur.
+// İşlemi yapanın sahip olmadığı izinleri içeren roller düzenlenemez.
+func (s *RoleService) UpdateRole(actorID, id uint, name, description string, permissionKeys []string) error {
+	role, err := s.GetRoleByID(id)
+	if err != nil {
+		return err
+	}
+	currentKeys := make([]string, 0, len(role.Permissions))
+	for _, p := range role.Permissions {
+		currentKeys = append(currentKeys, p.Key)
+	}
+	if err := s.ensureActorHolds(actorID, currentKeys, ErrRoleEditExceeds); err != nil {
+		return err
+	}
+
+	role.Name = strings.TrimSpace(name)
+	role.Description = strings.TrimSpace(description)
+	if role.Name == "" {
+		return ErrRoleNameRequired
+	}
+
+	if role.BuiltIn && role.Slug == models.RoleSlugSystem {
+		permissionKeys = make([]string, 0, len(models.PermissionDefinitions()))
+		for _, p := range models.PermissionDefinitions() {
+			permissionKeys = append(permissionKeys, p.Key)
+		}
+	}
+
+	if err := s.ensureActorHolds(actorID, permissionKeys, ErrRoleGrantExceeds); err != nil {
+		return err
+	}
+
+	permissions, err := s.repo.FindPermissionsByKeys(permissionKeys)
+	if err != nil {
+		utils.Log.Error("Rol güncelleme: İzinler okunamadı", zap.Uint("role_id", id), zap.Error(err))
+		return ErrPermissionLoadError
+	}
+
+	if err := s.repo.Update(role, permissions); err != nil {
+		utils.Log.Error("Rol güncellenemedi", zap.Uint("role_id", id), zap.Error(err))
+		return ErrRoleSaveFailed
+	}
+
+	utils.Log.Info("Rol güncellendi", zap.Uint("role_id", id), zap.Int("permissions", len(permissions)))
+	return nil
+}
+
+func (s *RoleService) DeleteRole(id uint) error {
+	role, err := s.GetRoleByID(id)
+	if err != nil {
+		return err
+	}
+	if role.BuiltIn {
+		return ErrRoleBuiltInDelete
+	}
+
+	if err := s.repo.Delete(id); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return ErrRoleNotFound
+		}
+		utils.Log.Error("Rol silinemedi", zap.Uint("role_id", id), zap.Error(err))
+		return ErrRoleDeleteFailed
+	}
+
+	utils.Log.Info("Rol silindi", zap.Uint("role_id", id), zap.String("slug", role.Slug))
+	return nil
+}
+
+func (s *RoleService) CountRoleUsers(id uint) int64 {
+	count, err := s.repo.CountUsers(id)
+	if err != nil {
+		utils.Log.Warn("Role atanmış kullanıcı sayısı alınamadı", zap.Uint("role_id", id), zap.Error(err))
+		return 0
+	}
+	return count
+}
+
+func (s *RoleService) GetUserPermissions(userID uint) (map[string]bool, error) {
+	keys, err := s.repo.FindPermissionKeysByUser(userID)
+	if err != nil {
+		utils.Log.Error("Kullanıcı izinleri alınamadı", zap.Uint("user_id", userID), zap.Error(err))
+		return nil, ErrPermissionLoadError
+	}
+
+	permissions := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		permissions[key] = true
+	}
+	return permissions, nil
+}
+
+func (s *RoleService) HasPermission(userID uint, permission string) bool {
+	permissions, err := s.GetUserPermissions(userID)
+	if err != nil {
+		return false
+	}
+	return permissions[permission]
+}
+
+func (s *RoleService) AuthorizeRoleChange(actorID uint, userType models.UserType, current, requested []uint, explicit bool) error {
+	next, err := s.ResolveRoles(userType, requested)
+	if err != nil {
+		utils.Log.Error("Atanacak roller okunamadı", zap.Uint("actor_id", actorID), zap.Error(err))
+		return ErrRoleAssignFailed
+	}
+
+	currentSet := make(map[uint]bool, len(current))
+	for _, id := range current {
+		currentSet[id] = true
+	}
+	var added []models.Role
+	for _, role := range next {
+		if currentSet[role.ID] {
+			delete(currentSet, role.ID)
+		} else {
+			added = append(added, role)
+		}
+	}
+	if len(added) == 0 && len(currentSet) == 0 {
+		return nil
+	}
+
+	permissions, err := s.GetUserPermissions(actorID)
+	if err != nil {
+		return err
+	}
+	if explicit && !permissions[models.PermRolesManage] {
+		utils.Log.Warn("Rol yönetimi yetkisi olmadan rol değişikliği engellendi", zap.Uint("actor_id", actorID))
+		return ErrRoleAssignForbidden
+	}
+	for _, role := range added {
+		for _, permission := range role.Permissions {
+			if !permissions[permission.Key] {
+				utils.Log.Warn("Sahip olunmayan izinleri içeren rol ataması engellendi",
+					zap.Uint("actor_id", actorID), zap.String("role", role.Slug), zap.String("permission", permission.Key))
+				return ErrRoleGrantExceeds
+			}
+		}
+	}
+	return nil
+}
+
+// ensureActorHolds, actorID'nin verilen izinlerin tamamına sahip olduğunu denetler; eksik izin varsa denied döner.
+func (s *RoleService) ensureActorHolds(actorID uint, keys []string, denied error) error {
+	permissions, err := s.GetUserPermissions(actorID)
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if !permissions[key] {
+			utils.Log.Warn("Sahip olunmayan izinle rol işlemi engellendi", zap.Uint("actor_id", actorID), zap.String("permission", key))
+			return denied
+		}
+	}
+	return nil
+}
+
+// GetDefaultRole, kullanıcı tipine karşılık gelen yerleşik rolü döner.
+func (s *RoleService) GetDefaultRole(userType models.UserType) (*models.Role, error) {
+	role, err := s.repo.FindBySlug(models.DefaultRoleSlugFor(userType))
+	if err != nil {
+		utils.Log.Error("Yerleşik rol alınamadı", zap.String("type", string(userType)), zap.Error(err))
+		return nil, ErrRoleNotFound
+	}
+	return role, nil
+}
+
+func (s *RoleService) ResolveRoles(userType models.UserType, roleIDs []uint) ([]models.Role, error) {
+	roles, err := s.repo.FindByIDs(roleIDs)
+	if err != nil || len(roles) > 0 {
+		return roles, err
+	}
+	defaultRole, err := s.repo.FindBySlug(models.DefaultRoleSlugFor(userType))
+	if err != nil {
+		return nil, err
+	}
+	return []models.Role{*defaultRole}, nil
+}
+
+var _ IRoleService = (*RoleService)(nil)
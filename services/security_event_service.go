@@ -0,0 +1,97 @@
+package services
+
+import (
+	"zatrano/models"
+	"zatrano/repositories"
+	"zatrano/utils"
+
+	"go.uber.org/zap"
+)
+
+const recentSecurityEventLimit = 10
+
+type ISecurityEventService interface {
+	Record(event *models.SecurityEvent)
+	GetRecentForUser(userID uint) ([]models.SecurityEvent, error)
+	GetEventsPaginated(params utils.ListParams, filter utils.SecurityEventFilter) (*utils.PaginatedResult, error)
+}
+
+type SecurityEventService struct {
+	repo     repositories.ISecurityEventRepository
+	authRepo repositories.IAuthRepository
+}
+
+func NewSecurityEventService() ISecurityEventService {
+	return &SecurityEventService{
+		repo:     repositories.NewSecurityEventRepository(),
+		authRepo: repositories.NewAuthRepository(),
+	}
+}
+
+// Record, olayı kaydeder. Kayıt hatası isteği etkilememesi için yalnızca loglanır.
+func (s *SecurityEventService) Record(event *models.SecurityEvent) {
+	if event.UserID == nil && event.Account != "" {
+		if user, err := s.authRepo.FindUserByAccount(event.Account); err == nil {
+			event.UserID = &user.ID
+		}
+	} else if event.UserID != nil && event.Account == "" {
+		if user, err := s.authRepo.FindUserByID(*event.UserID); err == nil {
+			event.Account = user.Account
+		}
+	}
+	if len(event.Account) > 100 {
+		event.Account = event.Account[:100]
+	}
+	if len(event.UserAgent) > 255 {
+		event.UserAgent = event.UserAgent[:255]
+	}
+
+	if err := s.repo.Create(event); err != nil {
+		utils.Log.Error("Güvenlik olayı kaydedilemedi",
+			zap.String("type", string(event.Type)),
+			zap.String("account", event.Account),
+			zap.Error(err),
+		)
+	}
+}
+
+func (s *SecurityEventService) GetRecentForUser(userID uint) ([]models.SecurityEvent, error) {
+	events, err := s.repo.FindRecentByUser(userID, recentSecurityEventLimit)
+	if err != nil {
+		utils.Log.Error("Kullanıcının güvenlik olayları alınamadı", zap.Uint("user_id", userID), zap.Error(err))
+		return nil, err
+	}
+	return events, nil
+}
+
+func (s *SecurityEventService) GetEventsPaginated(params utils.ListParams, filter utils.SecurityEventFilter) (*utils.PaginatedResult, error) {
+	if params.Page <= 0 {
+		params.Page = utils.DefaultPage
+	}
+	if params.PerPage <= 0 || params.PerPage > utils.MaxPerPage {
+		params.PerPage = utils.DefaultPerPage
+	}
+	if params.SortBy == "" {
+		params.SortBy = utils.DefaultSortBy
+	}
+	if params.OrderBy == "" {
+		params.OrderBy = utils.DefaultOrderBy
+	}
+
+	events, totalCount, err := s.repo.FindAndPaginate(params, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &utils.PaginatedResult{
+		Data: events,
+		Meta: utils.PaginationMeta{
+			CurrentPage: params.Page,
+			PerPage:     params.PerPage,
+			TotalItems:  totalCount,
+			TotalPages:  utils.CalculateTotalPages(totalCount, params.PerPage),
+		},
+	}, nil
+}
+
+var _ ISecurityEventService = (*SecurityEventService)(nil)
@@ -0,0 +1,192 @@
+package services
+
+import (
+	"time"
+
+	"zatrano/models"
+	"zatrano/repositories"
+	"zatrano/utils"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	ErrSessionRevoked       ServiceError = "oturum sonlandırılmış"
+	ErrSessionNotFound      ServiceError = "oturum bulunamadı"
+	ErrSessionServiceFailed ServiceError = "oturum işlemi sırasında bir hata oluştu"
+)
+
+const sessionTouchInterval = time.Minute
+
+type ISessionService interface {
+	Register(userID uint, sessionID, ip, userAgent, rememberSelector string) error
+	Touch(sessionID string, userID uint) error
+	ListActive(userID uint) ([]models.UserSession, error)
+	IsCurrent(userSession models.UserSession, sessionID string) bool
+	Revoke(userID, userSessionID uint) error
+	RevokeAll(userID uint) (int64, error)
+	RevokeOthers(userID uint, currentSessionID string) (int64, error)
+	Remove(sessionID string) error
+}
+
+type SessionService struct {
+	repo         repositories.IUserSessionRepository
+	rememberRepo repositories.IRememberTokenRepository
+	expiration   time.Duration
+}
+
+func NewSessionService() ISessionService {
+	return &SessionService{
+		repo:         repositories.NewUserSessionRepository(),
+		rememberRepo: repositories.NewRememberTokenRepository(),
+		expiration:   time.Duration(utils.GetEnvAsInt("SESSION_EXPIRATION_HOURS", 24)) * time.Hour,
+	}
+}
+
+// Register, oturumu kaydeder. rememberSelector boş değilse oturum sonlandırıldığında ilgili
+// "beni hatırla" anahtarı da silinir; böylece cihaz sessizce yeniden giriş yapamaz.
+func (s *SessionService) Register(userID uint, sessionID, ip, userAgent, rememberSelector string) error {
+	now := time.Now().UTC()
+	if err := s.repo.DeleteStale(userID, now.Add(-s.expiration)); err != nil {
+		utils.Log.Warn("Eski oturum kayıtları temizlenemedi", zap.Uint("user_id", userID), zap.Error(err))
+	}
+
+	if len(userAgent) > 255 {
+		userAgent = userAgent[:255]
+	}
+
+	userSession := &models.UserSession{
+		UserID:           userID,
+		SessionHash:      utils.HashToken(sessionID),
+		IP:               ip,
+		UserAgent:        userAgent,
+		RememberSelector: rememberSelector,
+		LastActivityAt:   now,
+	}
+	if err := s.repo.Create(userSession); err != nil {
+		utils.Log.Error("Oturum kaydı oluşturulamadı", zap.Uint("user_id", userID), zap.Error(err))
+		return ErrSessionServiceFailed
+	}
+	return nil
+}
+
+// Touch, oturumun hâlâ geçerli olduğunu doğrular ve son etkinlik zamanını en fazla dakikada bir günceller.
+func (s *SessionService) Touch(sessionID string, userID uint) error {
+	userSession, err := s.repo.FindByHash(utils.HashToken(sessionID))
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return ErrSessionRevoked
+		}
+		utils.Log.Error("Oturum kaydı okunamadı", zap.Uint("user_id", userID), zap.Error(err))
+		return ErrSessionServiceFailed
+	}
+	if userSession.UserID != userID {
+		return ErrSessionRevoked
+	}
+
+	now := time.Now().UTC()
+	if now.Sub(userSession.LastActivityAt) >= sessionTouchInterval {
+		if err := s.repo.TouchIfOlder(userSession.ID, now, now.Add(-sessionTouchInterval)); err != nil {
+			utils.Log.Warn("Oturum son etkinlik zamanı güncellenemedi", zap.Uint("user_id", userID), zap.Error(err))
+		}
+	}
+	return nil
+}
+
+func (s *SessionService) ListActive(userID uint) ([]models.UserSession, error) {
+	sessions, err := s.repo.FindActiveByUser(userID, time.Now().UTC().Add(-s.expiration))
+	if err != nil {
+		utils.Log.Error("Aktif oturumlar alınamadı", zap.Uint("user_id", userID), zap.Error(err))
+		return nil, ErrSessionServiceFailed
+	}
+	return sessions, nil
+}
+
+func (s *SessionService) IsCurrent(userSession models.UserSession, sessionID string) bool {
+	return utils.TokenHashEquals(userSession.SessionHash, utils.HashToken(sessionID))
+}
+
+func (s *SessionService) Revoke(userID, userSessionID uint) error {
+	userSession, err := s.repo.FindByID(userID, userSessionID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return ErrSessionNotFound
+		}
+		utils.Log.Error("Oturum kaydı okunamadı", zap.Uint("user_id", userID), zap.Uint("session_id", userSessionID), zap.Error(err))
+		return ErrSessionServiceFailed
+	}
+	s.forgetRemembered(userSession.RememberSelector)
+
+	affected, err := s.repo.DeleteByID(userID, userSessionID)
+	if err != nil {
+		utils.Log.Error("Oturum sonlandırılamadı", zap.Uint("user_id", userID), zap.Uint("session_id", userSessionID), zap.Error(err))
+		return ErrSessionServiceFailed
+	}
+	if affected == 0 {
+		return ErrSessionNotFound
+	}
+
+	utils.Log.Info("Oturum sonlandırıldı", zap.Uint("user_id", userID), zap.Uint("session_id", userSessionID))
+	return nil
+}
+
+func (s *SessionService) RevokeAll(userID uint) (int64, error) {
+	if _, err := s.rememberRepo.DeleteByUser(userID); err != nil {
+		utils.Log.Error("Kullanıcının hatırlama anahtarları silinemedi", zap.Uint("user_id", userID), zap.Error(err))
+		return 0, ErrSessionServiceFailed
+	}
+
+	affected, err := s.repo.DeleteByUser(userID)
+	if err != nil {
+		utils.Log.Error("Kullanıcının tüm oturumları sonlandırılamadı", zap.Uint("user_id", userID), zap.Error(err))
+		return 0, ErrSessionServiceFailed
+	}
+
+	utils.Log.Info("Kullanıcının tüm oturumları sonlandırıldı", zap.Uint("user_id", userID), zap.Int64("count", affected))
+	return affected, nil
+}
+
+func (s *SessionService) RevokeOthers(userID uint, currentSessionID string) (int64, error) {
+	var keepSelector string
+	if current, err := s.repo.FindByHash(utils.HashToken(currentSessionID)); err == nil {
+		keepSelector = current.RememberSelector
+	}
+	if _, err := s.rememberRepo.DeleteByUserExcept(userID, keepSelector); err != nil {
+		utils.Log.Error("Diğer cihazların hatırlama anahtarları silinemedi", zap.Uint("user_id", userID), zap.Error(err))
+		return 0, ErrSessionServiceFailed
+	}
+
+	affected, err := s.repo.DeleteByUserExcept(userID, utils.HashToken(currentSessionID))
+	if err != nil {
+		utils.Log.Error("Diğer oturumlar sonlandırılamadı", zap.Uint("user_id", userID), zap.Error(err))
+		return 0, ErrSessionServiceFailed
+	}
+
+	utils.Log.Info("Kullanıcının diğer oturumları sonlandırıldı", zap.Uint("user_id", userID), zap.Int64("count", affected))
+	return affected, nil
+}
+
+func (s *SessionService) Remove(sessionID string) error {
+	sessionHash := utils.HashToken(sessionID)
+	if userSession, err := s.repo.FindByHash(sessionHash); err == nil {
+		s.forgetRemembered(userSession.RememberSelector)
+	}
+
+	if err := s.repo.DeleteByHash(sessionHash); err != nil {
+		utils.Log.Warn("Oturum kaydı silinemedi", zap.Error(err))
+		return ErrSessionServiceFailed
+	}
+	return nil
+}
+
+func (s *SessionService) forgetRemembered(selector string) {
+	if selector == "" {
+		return
+	}
+	if err := s.rememberRepo.DeleteBySelector(selector); err != nil {
+		utils.Log.Warn("Oturuma bağlı hatırlama anahtarı silinemedi", zap.Error(err))
+	}
+}
+
+var _ ISessionService = (*SessionService)(nil)
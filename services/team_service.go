@@ -0,0 +1,135 @@
+package services
+
+import (
+	"strings"
+
+	"zatrano/models"
+	"zatrano/repositories"
+	"zatrano/utils"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	ErrTeamNotFound     ServiceError = "takım bulunamadı"
+	ErrTeamNameRequired ServiceError = "takım adı boş olamaz"
+	ErrTeamSaveFailed   ServiceError = "takım kaydedilemedi"
+	ErrTeamDeleteFailed ServiceError = "takım silinemedi"
+)
+
+type ITeamService interface {
+	GetTeams() ([]models.Team, error)
+	GetTeamsWithMemberCount() ([]repositories.TeamWithCount, error)
+	GetTeamByID(id uint) (*models.Team, error)
+	GetMembers(teamID uint) ([]models.User, error)
+	CreateTeam(team *models.Team) error
+	UpdateTeam(id uint, team *models.Team) error
+	DeleteTeam(id uint) error
+}
+
+type TeamService struct {
+	repo repositories.ITeamRepository
+}
+
+func NewTeamService() ITeamService {
+	return &TeamService{repo: repositories.NewTeamRepository()}
+}
+
+func (s *TeamService) GetTeams() ([]models.Team, error) {
+	teams, err := s.repo.FindAll()
+	if err != nil {
+		utils.Log.Error("Takımlar alınamadı", zap.Error(err))
+		return nil, err
+	}
+	return teams, nil
+}
+
+func (s *TeamService) GetTeamsWithMemberCount() ([]repositories.TeamWithCount, error) {
+	teams, err := s.repo.FindAllWithMemberCount()
+	if err != nil {
+		utils.Log.Error("Takımlar üye sayılarıyla alınamadı", zap.Error(err))
+		return nil, err
+	}
+	return teams, nil
+}
+
+func (s *TeamService) GetTeamByID(id uint) (*models.Team, error) {
+	team, err := s.repo.FindByID(id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, ErrTeamNotFound
+		}
+		utils.Log.Error("Takım alınamadı", zap.Uint("team_id", id), zap.Error(err))
+		return nil, err
+	}
+	return team, nil
+}
+
+func (s *TeamService) GetMembers(teamID uint) ([]models.User, error) {
+	members, err := s.repo.FindMembers(teamID)
+	if err != nil {
+		utils.Log.Error("Takım üyeleri alınamadı", zap.Uint("team_id", teamID), zap.Error(err))
+		return nil, err
+	}
+	return members, nil
+}
+
+func (s *TeamService) CreateTeam(team *models.Team) error {
+	team.Name = strings.TrimSpace(team.Name)
+	if team.Name == "" {
+		return ErrTeamNameRequired
+	}
+
+	if err := s.repo.Create(team); err != nil {
+		utils.Log.Error("Takım oluşturulamadı", zap.String("name", team.Name), zap.Error(err))
+		return ErrTeamSaveFailed
+	}
+	// Status false ise sütun varsayılanı (true) uygulanmış olur; pasif takımlar ayrıca güncellenir.
+	if !team.Status {
+		if err := s.repo.Update(team.ID, map[string]interface{}{"status": false}); err != nil {
+			utils.Log.Warn("Yeni takımın durumu güncellenemedi", zap.Uint("team_id", team.ID), zap.Error(err))
+		}
+	}
+
+	utils.Log.Info("Takım oluşturuldu", zap.Uint("team_id", team.ID), zap.String("name", team.Name))
+	return nil
+}
+
+func (s *TeamService) UpdateTeam(id uint, team *models.Team) error {
+	name := strings.TrimSpace(team.Name)
+	if name == "" {
+		return ErrTeamNameRequired
+	}
+
+	err := s.repo.Update(id, map[string]interface{}{
+		"name":        name,
+		"description": strings.TrimSpace(team.Description),
+		"status":      team.Status,
+	})
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return ErrTeamNotFound
+		}
+		utils.Log.Error("Takım güncellenemedi", zap.Uint("team_id", id), zap.Error(err))
+		return ErrTeamSaveFailed
+	}
+
+	utils.Log.Info("Takım güncellendi", zap.Uint("team_id", id))
+	return nil
+}
+
+func (s *TeamService) DeleteTeam(id uint) error {
+	if err := s.repo.Delete(id); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return ErrTeamNotFound
+		}
+		utils.Log.Error("Takım silinemedi", zap.Uint("team_id", id), zap.Error(err))
+		return ErrTeamDeleteFailed
+	}
+
+	utils.Log.Info("Takım silindi", zap.Uint("team_id", id))
+	return nil
+}
+
+var _ ITeamService = (*TeamService)(nil)
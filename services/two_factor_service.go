@@ -0,0 +1,260 @@
+package services
+
+import (
+	"strings"
+	"time"
+
+	"zatrano/models"
+	"zatrano/repositories"
+	"zatrano/utils"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	ErrTwoFactorInvalidCode      ServiceError = "doğrulama kodu geçersiz"
+	ErrTwoFactorNotEnabled       ServiceError = "iki adımlı doğrulama etkin değil"
+	ErrTwoFactorAlreadyEnabled   ServiceError = "iki adımlı doğrulama zaten etkin"
+	ErrTwoFactorRequiredByPolicy ServiceError = "iki adımlı doğrulama bu kullanıcı tipi için zorunludur"
+	ErrTwoFactorGeneric          ServiceError = "iki adımlı doğrulama işlemi sırasında bir hata oluştu"
+)
+
+const recoveryCodeCount = 10
+
+type ITwoFactorService interface {
+	NewSecret() (string, error)
+	ProvisioningURI(account, secret string) string
+	ConfirmEnrollment(userID uint, secret, code string) ([]string, error)
+	VerifyCode(userID uint, code string) error
+	Disable(userID uint, code string) error
+	RegenerateRecoveryCodes(userID uint, code string) ([]string, error)
+	ResetForUser(userID uint) error
+	RemainingRecoveryCodes(userID uint) int64
+	IsRequiredFor(userType models.UserType) bool
+}
+
+type TwoFactorService struct {
+	repo          repositories.ITwoFactorRepository
+	authRepo      repositories.IAuthRepository
+	policyService IUserTypePolicyService
+	issuer        string
+}
+
+func NewTwoFactorService() ITwoFactorService {
+	return &TwoFactorService{
+		repo:          repositories.NewTwoFactorRepository(),
+		authRepo:      repositories.NewAuthRepository(),
+		policyService: NewUserTypePolicyService(),
+		issuer:        utils.GetEnvWithDefault("TOTP_ISSUER", "zatrano"),
+	}
+}
+
+func (s *TwoFactorService) NewSecret() (string, error) {
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		utils.Log.Error("TOTP anahtarı üretilemedi", zap.Error(err))
+		return "", ErrTwoFactorGeneric
+	}
+	return secret, nil
+}
+
+func (s *TwoFactorService) ProvisioningURI(account, secret string) string {
+	return utils.TOTPProvisioningURI(s.issuer, account, secret)
+}
+
+func (s *TwoFactorService) ConfirmEnrollment(userID uint, secret, code string) ([]string, error) {
+	user, err := s.findUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.TwoFactorEnabled {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+
+	step, ok := utils.ValidateTOTPCode(secret, code, time.Now())
+	if !ok {
+		utils.Log.Warn("2FA kurulumu başarısız: Geçersiz kod", zap.Uint("user_id", userID))
+		return nil, ErrTwoFactorInvalidCode
+	}
+
+	codes, err := s.replaceRecoveryCodes(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.repo.UpdateUserTwoFactor(userID, map[string]interface{}{
+		"two_factor_enabled":    true,
+		"two_factor_secret":     secret,
+		"two_factor_last_step":  step,
+		"two_factor_enabled_at": time.Now().UTC(),
+	})
+	if err != nil {
+		utils.Log.Error("2FA kurulumu kaydedilemedi", zap.Uint("user_id", userID), zap.Error(err))
+		return nil, ErrTwoFactorGeneric
+	}
+
+	utils.Log.Info("İki adımlı doğrulama etkinleştirildi", zap.Uint("user_id", userID))
+	return codes, nil
+}
+
+// VerifyCode, önce TOTP kodunu, olmazsa kullanılmamış bir kurtarma kodunu dener.
+func (s *TwoFactorService) VerifyCode(userID uint, code string) error {
+	user, err := s.findUser(userID)
+	if err != nil {
+		return err
+	}
+	if !user.TwoFactorEnabled {
+		return ErrTwoFactorNotEnabled
+	}
+
+	code = strings.TrimSpace(code)
+	if step, ok := utils.ValidateTOTPCode(user.TwoFactorSecret, code, time.Now()); ok {
+		if step <= user.TwoFactorLastStep {
+			utils.Log.Warn("2FA doğrulaması reddedildi: Kod daha önce kullanılmış", zap.Uint("user_id", userID))
+			return ErrTwoFactorInvalidCode
+		}
+		if err := s.repo.UpdateUserTwoFactor(userID, map[string]interface{}{"two_factor_last_step": step}); err != nil {
+			utils.Log.Error("2FA son adım bilgisi kaydedilemedi", zap.Uint("user_id", userID), zap.Error(err))
+			return ErrTwoFactorGeneric
+		}
+		return nil
+	}
+
+	used, err := s.repo.UseRecoveryCode(userID, utils.HashToken(normalizeRecoveryCode(code)))
+	if err != nil {
+		utils.Log.Error("Kurtarma kodu kontrol edilemedi", zap.Uint("user_id", userID), zap.Error(err))
+		return ErrTwoFactorGeneric
+	}
+	if !used {
+		utils.Log.Warn("2FA doğrulaması başarısız: Geçersiz kod", zap.Uint("user_id", userID))
+		return ErrTwoFactorInvalidCode
+	}
+
+	utils.Log.Info("2FA doğrulaması kurtarma kodu ile yapıldı", zap.Uint("user_id", userID))
+	return nil
+}
+
+func (s *TwoFactorService) Disable(userID uint, code string) error {
+	user, err := s.findUser(userID)
+	if err != nil {
+		return err
+	}
+	if s.IsRequiredFor(user.Type) {
+		return ErrTwoFactorRequiredByPolicy
+	}
+	if err := s.VerifyCode(userID, code); err != nil {
+		return err
+	}
+	if err := s.clearTwoFactor(userID); err != nil {
+		return err
+	}
+
+	utils.Log.Info("İki adımlı doğrulama kullanıcı tarafından kapatıldı", zap.Uint("user_id", userID))
+	return nil
+}
+
+func (s *TwoFactorService) RegenerateRecoveryCodes(userID uint, code string) ([]string, error) {
+	if err := s.VerifyCode(userID, code); err != nil {
+		return nil, err
+	}
+	codes, err := s.replaceRecoveryCodes(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	utils.Log.Info("Kurtarma kodları yenilendi", zap.Uint("user_id", userID))
+	return codes, nil
+}
+
+func (s *TwoFactorService) ResetForUser(userID uint) error {
+	if _, err := s.findUser(userID); err != nil {
+		return err
+	}
+	if err := s.clearTwoFactor(userID); err != nil {
+		return err
+	}
+
+	utils.Log.Info("İki adımlı doğrulama yönetici tarafından sıfırlandı", zap.Uint("user_id", userID))
+	return nil
+}
+
+func (s *TwoFactorService) RemainingRecoveryCodes(userID uint) int64 {
+	count, err := s.repo.CountUnusedRecoveryCodes(userID)
+	if err != nil {
+		utils.Log.Error("Kalan kurtarma kodu sayısı alınamadı", zap.Uint("user_id", userID), zap.Error(err))
+		return 0
+	}
+	return count
+}
+
+func (s *TwoFactorService) IsRequiredFor(userType models.UserType) bool {
+	return s.policyService.GetPolicy(userType).RequireTwoFactor
+}
+
+func (s *TwoFactorService) findUser(userID uint) (*models.User, error) {
+	user, err := s.authRepo.FindUserByID(userID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, ErrUserNotFound
+		}
+		utils.Log.Error("2FA: Kullanıcı alınamadı", zap.Uint("user_id", userID), zap.Error(err))
+		return nil, ErrTwoFactorGeneric
+	}
+	return user, nil
+}
+
+func (s *TwoFactorService) clearTwoFactor(userID uint) error {
+	err := s.repo.UpdateUserTwoFactor(userID, map[string]interface{}{
+		"two_factor_enabled":    false,
+		"two_factor_secret":     "",
+		"two_factor_last_step":  0,
+		"two_factor_enabled_at": nil,
+	})
+	if err != nil {
+		utils.Log.Error("2FA bilgileri temizlenemedi", zap.Uint("user_id", userID), zap.Error(err))
+		return ErrTwoFactorGeneric
+	}
+	if err := s.repo.DeleteRecoveryCodes(userID); err != nil {
+		utils.Log.Error("Kurtarma kodları silinemedi", zap.Uint("user_id", userID), zap.Error(err))
+		return ErrTwoFactorGeneric
+	}
+	return nil
+}
+
+func (s *TwoFactorService) replaceRecoveryCodes(userID uint) ([]string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		code, err := generateRecoveryCode()
+		if err != nil {
+			utils.Log.Error("Kurtarma kodu üretilemedi", zap.Uint("user_id", userID), zap.Error(err))
+			return nil, ErrTwoFactorGeneric
+		}
+		codes = append(codes, code)
+		hashes = append(hashes, utils.HashToken(normalizeRecoveryCode(code)))
+	}
+
+	if err := s.repo.ReplaceRecoveryCodes(userID, hashes); err != nil {
+		utils.Log.Error("Kurtarma kodları kaydedilemedi", zap.Uint("user_id", userID), zap.Error(err))
+		return nil, ErrTwoFactorGeneric
+	}
+	return codes, nil
+}
+
+func generateRecoveryCode() (string, error) {
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		return "", err
+	}
+	code := strings.ToLower(secret[:10])
+	return code[:5] + "-" + code[5:], nil
+}
+
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	code = strings.ReplaceAll(code, "-", "")
+	return strings.ReplaceAll(code, " ", "")
+}
+
+var _ ITwoFactorService = (*TwoFactorService)(nil)
@@ -0,0 +1,211 @@
+package services
+
+import (
+	"zatrano/models"
+	"zatrano/utils"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	ErrUserBulkEmpty     UserServiceError = "işlem için en az bir kullanıcı seçilmelidir"
+	ErrUserBulkFailed    UserServiceError = "toplu işlem uygulanamadı, hiçbir kullanıcı değiştirilmedi"
+	ErrUserProtected     UserServiceError = "korumalı sistem kullanıcısı başka kullanıcılar tarafından değiştirilemez veya silinemez"
+	ErrUserSelfOperation UserServiceError = "kendi hesabınız üzerinde bu işlem yapılamaz"
+	ErrUserTypeUnchanged UserServiceError = "kullanıcı zaten bu tipte"
+)
+
+// BulkSkip, toplu işlemde atlanan bir kullanıcıyı ve atlanma nedenini tutar.
+type BulkSkip struct {
+	ID      uint
+	Account string
+	Reason  error
+}
+
+// BulkResult, toplu işlemde değiştirilen ve atlanan kullanıcıları raporlar.
+type BulkResult struct {
+	Succeeded []uint
+	Skipped   []BulkSkip
+}
+
+func (r *BulkResult) skip(id uint, account string, reason error) {
+	r.Skipped = append(r.Skipped, BulkSkip{ID: id, Account: account, Reason: reason})
+}
+
+// BulkSetStatus, seçilen kullanıcıları tek işlemde aktif veya pasif yapar; pasif yapılanların oturumları sonlandırılır.
+func (s *UserService) BulkSetStatus(actorID uint, scope UserScope, ids []uint, status bool) (*BulkResult, error) {
+	data := map[string]interface{}{"status": status}
+	if !status {
+		data["session_version"] = models.NextSessionVersion()
+	}
+
+	result, err := s.applyBulk(actorID, scope, ids, !status, nil, func(eligible []uint) error {
+		return s.repo.BulkUpdate(eligible, data)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !status {
+		s.revokeSessions(result.Succeeded)
+	}
+	utils.Log.Info("Toplu durum güncellemesi uygulandı", zap.Bool("status", status),
+		zap.Int("succeeded", len(result.Succeeded)), zap.Int("skipped", len(result.Skipped)))
+	return result, nil
+}
+
+// BulkSetType, seçilen kullanıcıların tipini ve rollerini yeni tipin yerleşik rolüyle tek işlemde değiştirir.
+// Tip değişikliği mevcut oturumları geçersiz kılar. Yalnızca tüm takımları yönetebilen kullanıcılar tip değiştirebilir;
+// tipi zaten aynı olanlar ve rol yönetimi yetkisi olmadan ek rolleri silinecek olanlar atlanır.
+func (s *UserService) BulkSetType(actorID uint, scope UserScope, ids []uint, userType models.UserType) (*BulkResult, error) {
+	if userType != models.System && userType != models.Panel {
+		return nil, models.ErrInvalidUserType
+	}
+	if !scope.AllTeams {
+		return nil, ErrUserOutOfScope
+	}
+	if err := s.roles.AuthorizeRoleChange(actorID, userType, nil, nil, false); err != nil {
+		return nil, err
+	}
+	role, err := s.roles.GetDefaultRole(userType)
+	if err != nil {
+		return nil, err
+	}
+	canManageRoles := s.roles.HasPermission(actorID, models.PermRolesManage)
+
+	check := func(user *models.User) error {
+		if user.Type == userType {
+			return ErrUserTypeUnchanged
+		}
+		if !canManageRoles && hasExtraRoles(user) {
+			return ErrRoleAssignForbidden
+		}
+		return nil
+	}
+	result, err := s.applyBulk(actorID, scope, ids, userType != models.System, check, func(eligible []uint) error {
+		return s.repo.BulkUpdateWithRole(eligible, map[string]interface{}{
+			"type":            userType,
+			"session_version": models.NextSessionVersion(),
+		}, role.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	utils.Log.Info("Toplu tip güncellemesi uygulandı", zap.String("type", string(userType)),
+		zap.Int("succeeded", len(result.Succeeded)), zap.Int("skipped", len(result.Skipped)))
+	return result, nil
+}
+
+// BulkDelete, seçilen kullanıcıları tek işlemde silinen kullanıcılar listesine taşır ve oturumlarını sonlandırır.
+func (s *UserService) BulkDelete(actorID uint, scope UserScope, ids []uint) (*BulkResult, error) {
+	result, err := s.applyBulk(actorID, scope, ids, true, nil, s.repo.BulkDelete)
+	if err != nil {
+		return nil, err
+	}
+
+	s.revokeSessions(result.Succeeded)
+	utils.Log.Info("Toplu silme uygulandı",
+		zap.Int("succeeded", len(result.Succeeded)), zap.Int("skipped", len(result.Skipped)))
+	return result, nil
+}
+
+// applyBulk, seçilen kullanıcılardan işlem yapılamayacak olanları nedenleriyle ayırır ve kalanlara
+// apply'ı tek seferde uygular. apply başarısız olursa hiçbir kullanıcı değiştirilmemiş olur.
+// revokesAccess, işlemin kullanıcıları pasif yaptığını veya sistem rolünden çıkardığını belirtir;
+// bu durumda en az bir aktif sistem yöneticisi bırakılır. check verilmişse hata döndürdüğü kullanıcılar bu nedenle atlanır.
+func (s *UserService) applyBulk(actorID uint, scope UserScope, ids []uint, revokesAccess bool, check func(user *models.User) error, apply func(eligible []uint) error) (*BulkResult, error) {
+	ids = uniqueIDs(ids)
+	if len(ids) == 0 {
+		return nil, ErrUserBulkEmpty
+	}
+
+	users, err := s.repo.FindByIDs(ids)
+	if err != nil {
+		utils.Log.Error("Toplu işlem: Kullanıcılar alınamadı", zap.Error(err))
+		return nil, ErrUserBulkFailed
+	}
+	found := make(map[uint]*models.User, len(users))
+	for i := range users {
+		found[users[i].ID] = &users[i]
+	}
+
+	result := &BulkResult{}
+	eligible := make([]uint, 0, len(ids))
+	for _, id := range ids {
+		user, ok := found[id]
+		switch {
+		case !ok:
+			result.skip(id, "", ErrUserServiceUserNotFound)
+		case user.Protected:
+			result.skip(id, user.Account, ErrUserProtected)
+		case id == actorID:
+			result.skip(id, user.Account, ErrUserSelfOperation)
+		case !s.CanManage(scope, user):
+			result.skip(id, user.Account, ErrUserOutOfScope)
+		default:
+			if check != nil {
+				if err := check(user); err != nil {
+					result.skip(id, user.Account, err)
+					continue
+				}
+			}
+			eligible = append(eligible, id)
+		}
+	}
+
+	if revokesAccess {
+		if eligible, err = s.keepAdmin(found, eligible, result); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(eligible) == 0 {
+		return result, nil
+	}
+
+	if err := apply(eligible); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			utils.Log.Warn("Toplu işlem: Seçilen kullanıcılardan biri işlem sırasında değişti, işlem geri alındı")
+		} else {
+			utils.Log.Error("Toplu işlem uygulanamadı", zap.Uints("user_ids", eligible), zap.Error(err))
+		}
+		return nil, ErrUserBulkFailed
+	}
+
+	result.Succeeded = eligible
+	return result, nil
+}
+
+func (s *UserService) revokeSessions(ids []uint) {
+	for _, id := range ids {
+		if _, err := s.sessions.RevokeAll(id); err != nil {
+			utils.Log.Warn("Toplu işlem: Kullanıcının oturumları sonlandırılamadı", zap.Uint("user_id", id), zap.Error(err))
+		}
+	}
+}
+
+func uniqueIDs(ids []uint) []uint {
+	seen := make(map[uint]bool, len(ids))
+	unique := make([]uint, 0, len(ids))
+	for _, id := range ids {
+		if id == 0 || seen[id] {
+			continue
+		}
+		seen[id] = true
+		unique = append(unique, id)
+	}
+	return unique
+}
+
+// hasExtraRoles, kullanıcının tipinin yerleşik rolü dışında bir rolü olup olmadığını döner.
+func hasExtraRoles(user *models.User) bool {
+	defaultSlug := models.DefaultRoleSlugFor(user.Type)
+	for _, role := range user.Roles {
+		if role.Slug != defaultSlug {
+			return true
+		}
+	}
+	return false
+}
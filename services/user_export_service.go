@@ -0,0 +1,88 @@
+package services
+
+import (
+	"io"
+	"strconv"
+	"strings"
+
+	"zatrano/models"
+	"zatrano/spreadsheet"
+	"zatrano/utils"
+
+	"go.uber.org/zap"
+)
+
+const ErrUserExportFailed UserServiceError = "kullanıcılar dışa aktarılamadı"
+
+// ExportBatchSize, dışa aktarmada veritabanından tek seferde okunan kullanıcı sayısıdır.
+const ExportBatchSize = 500
+
+// userExportColumns, dışa aktarılan sütunlardır. Başlıklar içe aktarmanın tanıdığı adlarla aynıdır,
+// böylece dışa aktarılan dosya düzenlenip yeniden içe aktarılabilir.
+var userExportColumns = []spreadsheet.Column{
+	{Key: "id", Label: "ID"},
+	{Key: "name", Label: "Ad Soyad"},
+	{Key: "account", Label: "Hesap"},
+	{Key: "type", Label: "Tip"},
+	{Key: "status", Label: "Durum"},
+	{Key: "team", Label: "Takım"},
+	{Key: "roles", Label: "Roller"},
+	{Key: "created_at", Label: "Oluşturulma"},
+}
+
+// ExportUsers, liste ekranıyla aynı filtre, sıralama ve kapsama uyan tüm kullanıcıları sayfa sınırı
+// olmadan istenen biçimde w'ye yazar. Kayıtlar ExportBatchSize'lık gruplar halinde okunur.
+func (s *UserService) ExportUsers(w io.Writer, format string, params utils.ListParams, filter utils.UserFilter, scope UserScope) error {
+	scope.restrict(&filter)
+
+	writer, err := spreadsheet.NewWriter(format, w, userExportColumns)
+	if err != nil {
+		return err
+	}
+
+	exported := 0
+	err = s.repo.FindInBatches(params, filter, ExportBatchSize, func(users []models.User) error {
+		for i := range users {
+			if err := writer.WriteRow(userExportRow(&users[i])); err != nil {
+				return err
+			}
+		}
+		exported += len(users)
+		return nil
+	})
+	if closeErr := writer.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		utils.Log.Error("Kullanıcılar dışa aktarılamadı", zap.String("format", format), zap.Int("exported", exported), zap.Error(err))
+		return ErrUserExportFailed
+	}
+
+	utils.Log.Info("Kullanıcılar dışa aktarıldı", zap.String("format", format), zap.Int("count", exported))
+	return nil
+}
+
+func userExportRow(user *models.User) []string {
+	status := "pasif"
+	if user.Status {
+		status = "aktif"
+	}
+	team := ""
+	if user.Team != nil {
+		team = user.Team.Name
+	}
+	roles := make([]string, 0, len(user.Roles))
+	for _, role := range user.Roles {
+		roles = append(roles, role.Name)
+	}
+	return []string{
+		strconv.FormatUint(uint64(user.ID), 10),
+		user.Name,
+		user.Account,
+		string(user.Type),
+		status,
+		team,
+		strings.Join(roles, ", "),
+		user.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
@@ -0,0 +1,262 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"zatrano/models"
+	"zatrano/passwordpolicy"
+	"zatrano/spreadsheet"
+	"zatrano/utils"
+
+	"go.uber.org/zap"
+)
+
+const (
+	ErrImportMissingColumns UserServiceError = "dosyada zorunlu sütunlar eksik: ad soyad (name) ve hesap (account)"
+	ErrImportTooManyRows    UserServiceError = "dosyada çok fazla satır var"
+	ErrImportNoRows         UserServiceError = "dosyada başlık satırından sonra içe aktarılacak satır yok"
+	ErrImportInvalidStatus  UserServiceError = "durum değeri anlaşılamadı (aktif/pasif, evet/hayır, 1/0 kullanın)"
+	ErrImportDuplicateRow   UserServiceError = "bu hesap adı dosyada birden fazla kez geçiyor"
+)
+
+// MaxImportRows, tek seferde içe aktarılabilecek en fazla kullanıcı satırıdır.
+const MaxImportRows = 1000
+
+// importColumnAliases, başlık satırında kabul edilen sütun adlarıdır (küçük harfe çevrilmiş olarak).
+var importColumnAliases = map[string]string{
+	"name": "name", "ad": "name", "ad soyad": "name", "isim": "name",
+	"account": "account", "hesap": "account", "hesap adı": "account",
+	"type": "type", "tip": "type", "kullanıcı tipi": "type",
+	"status": "status", "durum": "status",
+	"password": "password", "şifre": "password", "parola": "password",
+}
+
+// ImportRow, içe aktarma dosyasındaki tek bir kullanıcı satırının ayrıştırılmış ve doğrulanmış halidir.
+type ImportRow struct {
+	Line     int    `json:"line"`
+	Name     string `json:"name"`
+	Account  string `json:"account"`
+	Type     string `json:"type"`
+	Status   string `json:"status"`
+	Password string `json:"password,omitempty"`
+
+	Errors []string `json:"-"`
+}
+
+func (r ImportRow) Valid() bool {
+	return len(r.Errors) == 0
+}
+
+// GeneratesPassword, satırda şifre verilmediği için içe aktarımda şifre üretileceğini belirtir.
+func (r ImportRow) GeneratesPassword() bool {
+	return r.Password == ""
+}
+
+// ImportReport, içe aktarma önizlemesinin (dry-run) sonucudur.
+type ImportReport struct {
+	Rows    []ImportRow
+	Valid   int
+	Invalid int
+}
+
+// ImportedUser, içe aktarılan kullanıcıyı ve şifre üretildiyse tek seferlik gösterilecek şifresini tutar.
+type ImportedUser struct {
+	ID                uint
+	Name              string
+	Account           string
+	GeneratedPassword string
+}
+
+// ImportResult, içe aktarımın kesinleştirilmesi sonucunda oluşturulan ve atlanan satırları raporlar.
+type ImportResult struct {
+	Created []ImportedUser
+	Skipped []ImportRow
+}
+
+// ParseImportRows, ilk satırı başlık kabul ederek dosya satırlarını ImportRow listesine çevirir.
+func ParseImportRows(rows [][]string) ([]ImportRow, error) {
+	if len(rows) < 2 {
+		return nil, ErrImportNoRows
+	}
+	if len(rows) > 0 && spreadsheet.IsBlankRow(rows[0]) {
+		return nil, ErrImportMissingColumns
+	}
+	if len(rows)-1 > MaxImportRows {
+		return nil, fmt.Errorf("%w (en fazla %d)", ErrImportTooManyRows, MaxImportRows)
+	}
+
+	columns := make(map[string]int)
+	for i, header := range rows[0] {
+		if key, ok := importColumnAliases[strings.ToLower(strings.TrimSpace(header))]; ok {
+			if _, exists := columns[key]; !exists {
+				columns[key] = i
+			}
+		}
+	}
+	if _, ok := columns["name"]; !ok {
+		return nil, ErrImportMissingColumns
+	}
+	if _, ok := columns["account"]; !ok {
+		return nil, ErrImportMissingColumns
+	}
+
+	cell := func(row []string, key string) string {
+		i, ok := columns[key]
+		if !ok || i >= len(row) {
+			return ""
+		}
+		return strings.TrimSpace(row[i])
+	}
+
+	parsed := make([]ImportRow, 0, len(rows)-1)
+	for i, row := range rows[1:] {
+		if spreadsheet.IsBlankRow(row) {
+			continue
+		}
+		parsed = append(parsed, ImportRow{
+			// Başlık satırı 1. satır olduğundan veri satırları 2'den başlar.
+			Line:     i + 2,
+			Name:     cell(row, "name"),
+			Account:  cell(row, "account"),
+			Type:     strings.ToLower(cell(row, "type")),
+			Status:   cell(row, "status"),
+			Password: cell(row, "password"),
+		})
+	}
+	if len(parsed) == 0 {
+		return nil, ErrImportNoRows
+	}
+	return parsed, nil
+}
+
+// PreviewImport, satırları hiçbir kayıt oluşturmadan CreateUser ile aynı kurallara göre doğrular.
+func (s *UserService) PreviewImport(rows []ImportRow, scope UserScope) (*ImportReport, error) {
+	if !scope.AllTeams && scope.TeamID == nil {
+		return nil, ErrUserScopeNoTeam
+	}
+
+	report := &ImportReport{Rows: make([]ImportRow, len(rows))}
+	seen := make(map[string]int, len(rows))
+	for i, row := range rows {
+		row.Errors = nil
+		key := strings.ToLower(row.Account)
+		if key != "" {
+			if firstLine, duplicate := seen[key]; duplicate {
+				row.Errors = append(row.Errors, fmt.Sprintf("%s (ilk olarak %d. satırda)", ErrImportDuplicateRow.Error(), firstLine))
+			} else {
+				seen[key] = row.Line
+			}
+		}
+
+		user, err := s.importRowUser(row, scope)
+		if err != nil {
+			row.Errors = append(row.Errors, importErrorMessages(err)...)
+		} else if err := s.ValidateNewUser(user); err != nil {
+			row.Errors = append(row.Errors, importErrorMessages(err)...)
+		}
+
+		report.Rows[i] = row
+		if row.Valid() {
+			report.Valid++
+		} else {
+			report.Invalid++
+		}
+	}
+	return report, nil
+}
+
+// CommitImport, satırları yeniden doğrular ve geçerli olanları oluşturur. Şifresi verilmeyen
+// kullanıcılar için politikaya uygun bir şifre üretilir; tüm kullanıcılar ilk girişte şifre değiştirir.
+func (s *UserService) CommitImport(actorID uint, rows []ImportRow, scope UserScope) (*ImportResult, error) {
+	report, err := s.PreviewImport(rows, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ImportResult{}
+	for _, row := range report.Rows {
+		if !row.Valid() {
+			result.Skipped = append(result.Skipped, row)
+			continue
+		}
+
+		user, err := s.importRowUser(row, scope)
+		// CreateUser şifreyi hashlediğinden üretilen şifre önceden saklanır.
+		plainPassword := ""
+		if err == nil {
+			plainPassword = user.Password
+			err = s.CreateUser(actorID, user)
+		}
+		if err != nil {
+			row.Errors = importErrorMessages(err)
+			result.Skipped = append(result.Skipped, row)
+			continue
+		}
+
+		imported := ImportedUser{ID: user.ID, Name: user.Name, Account: user.Account}
+		if row.GeneratesPassword() {
+			imported.GeneratedPassword = plainPassword
+		}
+		result.Created = append(result.Created, imported)
+	}
+
+	utils.Log.Info("Kullanıcı içe aktarımı tamamlandı",
+		zap.Int("created", len(result.Created)), zap.Int("skipped", len(result.Skipped)))
+	return result, nil
+}
+
+// importRowUser, satırdan oluşturulacak kullanıcıyı hazırlar. Şifre verilmemişse üretilen şifre
+// Password alanına düz metin olarak yazılır; CreateUser onu hashler.
+func (s *UserService) importRowUser(row ImportRow, scope UserScope) (*models.User, error) {
+	status, err := parseImportStatus(row.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	userType := models.UserType(row.Type)
+	if row.Type == "" {
+		userType = models.Panel
+	}
+
+	user := &models.User{
+		Name:     row.Name,
+		Account:  row.Account,
+		Password: row.Password,
+		Status:   status,
+		Type:     userType,
+	}
+	// Takımla sınırlı yöneticiler yalnızca kendi takımlarına panel kullanıcısı ekleyebilir.
+	if !scope.AllTeams {
+		user.TeamID = scope.TeamID
+		user.Type = models.Panel
+	}
+
+	if user.Password == "" {
+		generated, err := s.passwords.PolicyFor(user.Type).Generate(user.Account)
+		if err != nil {
+			return nil, err
+		}
+		user.Password = generated
+	}
+	return user, nil
+}
+
+func parseImportStatus(value string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", "1", "true", "aktif", "evet", "yes", "active":
+		return true, nil
+	case "0", "false", "pasif", "hayır", "no", "inactive":
+		return false, nil
+	}
+	return false, ErrImportInvalidStatus
+}
+
+func importErrorMessages(err error) []string {
+	var policyErr *passwordpolicy.PolicyError
+	if errors.As(err, &policyErr) {
+		return policyErr.Messages()
+	}
+	return []string{err.Error()}
+}
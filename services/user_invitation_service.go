@@ -0,0 +1,219 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"zatrano/configs"
+	"zatrano/mailer"
+	"zatrano/models"
+	"zatrano/repositories"
+	"zatrano/utils"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	ErrInvitationInvalid       UserServiceError = "davet bağlantısı geçersiz veya süresi dolmuş"
+	ErrInvitationEmailRequired UserServiceError = "davet gönderebilmek için kullanıcının e-posta adresi girilmelidir"
+	ErrInvitationNotPending    UserServiceError = "kullanıcı daveti zaten kabul etmiş"
+	ErrInvitationFailed        UserServiceError = "davet gönderilemedi"
+	ErrInvitationAcceptFailed  UserServiceError = "davet kabul edilirken bir hata oluştu"
+)
+
+type IUserInvitationService interface {
+	// Send, bekleyen kullanıcıya yeni bir davet bağlantısı gönderir; önceki bağlantılar geçersiz olur.
+	Send(userID, invitedBy uint) error
+	// Revoke, bekleyen kullanıcının davet bağlantılarını geçersiz kılar; kullanıcı beklemede kalır.
+	Revoke(userID uint) error
+	ValidateToken(token string) (*models.User, error)
+	// Accept, davetli kullanıcının şifresini belirler ve hesabı etkinleştirir.
+	Accept(token, password string) (uint, error)
+}
+
+type UserInvitationService struct {
+	repo      repositories.IUserInvitationRepository
+	authRepo  repositories.IAuthRepository
+	passwords IPasswordPolicyService
+	mailer    mailer.Mailer
+	ttl       time.Duration
+	appURL    string
+}
+
+func NewUserInvitationService() IUserInvitationService {
+	return &UserInvitationService{
+		repo:      repositories.NewUserInvitationRepository(),
+		authRepo:  repositories.NewAuthRepository(),
+		passwords: NewPasswordPolicyService(),
+		mailer:    configs.GetMailer(),
+		ttl:       configs.GetInvitationTTL(),
+		appURL:    configs.GetAppURL(),
+	}
+}
+
+func (s *UserInvitationService) Send(userID, invitedBy uint) error {
+	user, err := s.pendingUser(userID)
+	if err != nil {
+		return err
+	}
+	if user.Email == "" {
+		return ErrInvitationEmailRequired
+	}
+
+	token, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		utils.Log.Error("Davet tokenı üretilemedi", zap.Uint("user_id", userID), zap.Error(err))
+		return ErrInvitationFailed
+	}
+
+	if err := s.repo.InvalidateForUser(userID); err != nil {
+		utils.Log.Error("Eski davetler geçersiz kılınamadı", zap.Uint("user_id", userID), zap.Error(err))
+		return ErrInvitationFailed
+	}
+
+	now := time.Now().UTC()
+	invitation := &models.UserInvitation{
+		UserID:      userID,
+		TokenHash:   utils.HashToken(token),
+		ExpiresAt:   now.Add(s.ttl),
+		InvitedByID: &invitedBy,
+	}
+	if err := s.repo.Create(invitation); err != nil {
+		utils.Log.Error("Davet kaydedilemedi", zap.Uint("user_id", userID), zap.Error(err))
+		return ErrInvitationFailed
+	}
+
+	err = s.authRepo.UpdateUserFields(userID, map[string]interface{}{
+		"invited_at":            now,
+		"invitation_expires_at": invitation.ExpiresAt,
+	})
+	if err != nil {
+		utils.Log.Error("Kullanıcının davet bilgisi güncellenemedi", zap.Uint("user_id", userID), zap.Error(err))
+		return ErrInvitationFailed
+	}
+
+	msg := mailer.Message{
+		To:      []string{user.Email},
+		Subject: "Hesabınız oluşturuldu",
+		TextBody: fmt.Sprintf("Merhaba %s,\n\n"+
+			"Sizin için \"%s\" hesap adıyla bir kullanıcı hesabı oluşturuldu. Şifrenizi belirleyip hesabınızı etkinleştirmek için aşağıdaki bağlantıyı kullanın:\n\n"+
+			"%s/auth/accept-invite/%s\n\n"+
+			"Bu bağlantı %d saat boyunca ve yalnızca bir kez geçerlidir.\n",
+			user.Name, user.Account, s.appURL, token, int(s.ttl.Hours())),
+	}
+
+	go func() {
+		if err := s.mailer.Send(msg); err != nil {
+			utils.Log.Error("Davet e-postası gönderilemedi", zap.Uint("user_id", userID), zap.Error(err))
+		}
+	}()
+
+	utils.Log.Info("Kullanıcı daveti gönderildi", zap.Uint("user_id", userID), zap.Uint("invited_by", invitedBy))
+	return nil
+}
+
+func (s *UserInvitationService) Revoke(userID uint) error {
+	if _, err := s.pendingUser(userID); err != nil {
+		return err
+	}
+	if err := s.repo.InvalidateForUser(userID); err != nil {
+		utils.Log.Error("Davetler geri alınamadı", zap.Uint("user_id", userID), zap.Error(err))
+		return ErrUserUpdateFailed
+	}
+	if err := s.authRepo.UpdateUserFields(userID, map[string]interface{}{"invitation_expires_at": nil}); err != nil {
+		utils.Log.Error("Kullanıcının davet bilgisi güncellenemedi", zap.Uint("user_id", userID), zap.Error(err))
+		return ErrUserUpdateFailed
+	}
+
+	utils.Log.Info("Kullanıcı daveti geri alındı", zap.Uint("user_id", userID))
+	return nil
+}
+
+func (s *UserInvitationService) ValidateToken(token string) (*models.User, error) {
+	invitation, err := s.findUsableInvitation(token)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.pendingUser(invitation.UserID)
+	if err != nil {
+		return nil, ErrInvitationInvalid
+	}
+	return user, nil
+}
+
+func (s *UserInvitationService) Accept(token, password string) (uint, error) {
+	invitation, err := s.findUsableInvitation(token)
+	if err != nil {
+		return 0, err
+	}
+	user, err := s.pendingUser(invitation.UserID)
+	if err != nil {
+		return 0, ErrInvitationInvalid
+	}
+
+	if err := s.passwords.Validate(user, password); err != nil {
+		return 0, err
+	}
+	if err := user.SetPassword(password); err != nil {
+		utils.Log.Error("Davet kabulü: Parola hashlenemedi", zap.Uint("user_id", user.ID), zap.Error(err))
+		return 0, ErrHashingFailed
+	}
+
+	claimed, err := s.repo.Redeem(invitation.ID, user.ID, map[string]interface{}{
+		"password":               user.Password,
+		"pending":                false,
+		"invitation_expires_at":  nil,
+		"credentials_changed_at": time.Now().UTC(),
+		"must_change_password":   false,
+		"session_version":        models.NextSessionVersion(),
+	})
+	if err != nil {
+		utils.Log.Error("Davet kabulü: Kullanıcı güncellenemedi", zap.Uint("user_id", user.ID), zap.Error(err))
+		return 0, ErrInvitationAcceptFailed
+	}
+	if !claimed {
+		return 0, ErrInvitationInvalid
+	}
+
+	s.passwords.RecordPassword(user.ID, user.Type, user.Password)
+
+	utils.Log.Info("Kullanıcı daveti kabul edildi", zap.Uint("user_id", user.ID))
+	return user.ID, nil
+}
+
+// pendingUser, daveti henüz kabul etmemiş kullanıcıyı döner.
+func (s *UserInvitationService) pendingUser(userID uint) (*models.User, error) {
+	user, err := s.authRepo.FindUserByID(userID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, ErrUserServiceUserNotFound
+		}
+		utils.Log.Error("Davet: Kullanıcı okunamadı", zap.Uint("user_id", userID), zap.Error(err))
+		return nil, err
+	}
+	if !user.Pending {
+		return nil, ErrInvitationNotPending
+	}
+	return user, nil
+}
+
+func (s *UserInvitationService) findUsableInvitation(token string) (*models.UserInvitation, error) {
+	if token == "" {
+		return nil, ErrInvitationInvalid
+	}
+	invitation, err := s.repo.FindByHash(utils.HashToken(token))
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, ErrInvitationInvalid
+		}
+		utils.Log.Error("Davet aranırken hata", zap.Error(err))
+		return nil, ErrInvitationAcceptFailed
+	}
+	if !invitation.IsUsable() {
+		return nil, ErrInvitationInvalid
+	}
+	return invitation, nil
+}
+
+var _ IUserInvitationService = (*UserInvitationService)(nil)
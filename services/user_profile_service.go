@@ -0,0 +1,154 @@
+package services
+
+import (
+	"bytes"
+	"io"
+	"net/mail"
+	"strconv"
+	"strings"
+
+	"zatrano/avatar"
+	"zatrano/configs"
+	"zatrano/utils"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	ErrUserInvalidEmail  UserServiceError = "geçerli bir e-posta adresi girin"
+	ErrUserInvalidPhone  UserServiceError = "telefon numarası 7-15 rakamdan oluşmalı; yalnızca rakam, boşluk, +, -, ( ) içerebilir"
+	ErrAvatarSaveFailed  UserServiceError = "profil resmi kaydedilemedi"
+	ErrProfileSaveFailed UserServiceError = "iletişim bilgileri kaydedilemedi"
+)
+
+// AvatarMaxKB, formlarda gösterilmek üzere yüklenebilecek en büyük profil resmi boyutunu KB olarak döner.
+func AvatarMaxKB() int64 {
+	return configs.AvatarMaxBytes() / 1024
+}
+
+// NormalizeContact, isteğe bağlı e-posta ve telefon alanlarını doğrular ve saklanacak biçime getirir.
+// Boş değerler geçerlidir ve alanın temizlendiği anlamına gelir.
+func NormalizeContact(email, phone string) (string, string, error) {
+	email = strings.TrimSpace(email)
+	if email != "" {
+		address, err := mail.ParseAddress(email)
+		if err != nil || address.Address != email || len(email) > 255 {
+			return "", "", ErrUserInvalidEmail
+		}
+		email = strings.ToLower(email)
+	}
+
+	phone = strings.Join(strings.Fields(phone), " ")
+	if phone != "" {
+		digits := 0
+		for i, r := range phone {
+			switch {
+			case r >= '0' && r <= '9':
+				digits++
+			case r == '+' && i == 0, r == ' ', r == '-', r == '(', r == ')':
+			default:
+				return "", "", ErrUserInvalidPhone
+			}
+		}
+		if digits < 7 || digits > 15 || len(phone) > 32 {
+			return "", "", ErrUserInvalidPhone
+		}
+	}
+	return email, phone, nil
+}
+
+// UpdateContactInfo, kullanıcının kendi profilinden değiştirebildiği e-posta ve telefon bilgilerini kaydeder.
+func (s *UserService) UpdateContactInfo(id uint, email, phone string) error {
+	email, phone, err := NormalizeContact(email, phone)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.Update(id, map[string]interface{}{"email": email, "phone": phone}); err != nil {
+		utils.Log.Error("İletişim bilgileri kaydedilemedi", zap.Uint("user_id", id), zap.Error(err))
+		return ErrProfileSaveFailed
+	}
+	return nil
+}
+
+// SetAvatar, yüklenen resmi doğrulayıp küçük resimlerini depoya yazar ve kullanıcının eski profil resmini
+// siler. Her yüklemede yeni bir anahtar üretildiği için eski adresler tarayıcı önbelleğinde kalmaz.
+func (s *UserService) SetAvatar(id uint, upload io.Reader) error {
+	user, err := s.repo.FindByID(id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return ErrUserServiceUserNotFound
+		}
+		return ErrAvatarSaveFailed
+	}
+
+	thumbnails, err := avatar.Process(upload, configs.AvatarMaxBytes())
+	if err != nil {
+		return err
+	}
+
+	token, err := utils.GenerateRandomToken(9)
+	if err != nil {
+		return ErrAvatarSaveFailed
+	}
+	baseKey := "avatars/" + strconv.FormatUint(uint64(id), 10) + "/" + token
+	for size, data := range thumbnails {
+		if err := s.files.Put(avatarKey(baseKey, size), bytes.NewReader(data), int64(len(data)), "image/png"); err != nil {
+			utils.Log.Error("Profil resmi depoya yazılamadı", zap.Uint("user_id", id), zap.Error(err))
+			s.deleteAvatarFiles(baseKey)
+			return ErrAvatarSaveFailed
+		}
+	}
+
+	if err := s.repo.Update(id, map[string]interface{}{"avatar_key": baseKey}); err != nil {
+		utils.Log.Error("Profil resmi kullanıcıya kaydedilemedi", zap.Uint("user_id", id), zap.Error(err))
+		s.deleteAvatarFiles(baseKey)
+		return ErrAvatarSaveFailed
+	}
+	s.deleteAvatarFiles(user.AvatarKey)
+
+	utils.Log.Info("Profil resmi güncellendi", zap.Uint("user_id", id))
+	return nil
+}
+
+func (s *UserService) RemoveAvatar(id uint) error {
+	user, err := s.repo.FindByID(id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return ErrUserServiceUserNotFound
+		}
+		return ErrAvatarSaveFailed
+	}
+	if user.AvatarKey == "" {
+		return nil
+	}
+	if err := s.repo.Update(id, map[string]interface{}{"avatar_key": ""}); err != nil {
+		utils.Log.Error("Profil resmi kaldırılamadı", zap.Uint("user_id", id), zap.Error(err))
+		return ErrAvatarSaveFailed
+	}
+	s.deleteAvatarFiles(user.AvatarKey)
+	return nil
+}
+
+// deleteAvatarFiles, bir profil resminin tüm küçük resimlerini siler; hatalar yalnızca loglanır.
+func (s *UserService) deleteAvatarFiles(baseKeys ...string) {
+	var keys []string
+	for _, baseKey := range baseKeys {
+		if baseKey == "" {
+			continue
+		}
+		for _, size := range avatar.Sizes {
+			keys = append(keys, avatarKey(baseKey, size))
+		}
+	}
+	if len(keys) == 0 {
+		return
+	}
+	if err := s.files.Delete(keys...); err != nil {
+		utils.Log.Warn("Eski profil resmi dosyaları silinemedi", zap.Strings("keys", keys), zap.Error(err))
+	}
+}
+
+func avatarKey(baseKey string, size avatar.Size) string {
+	return baseKey + "_" + size.Name + ".png"
+}
@@ -0,0 +1,113 @@
+package services
+
+import (
+	"errors"
+
+	"zatrano/models"
+	"zatrano/repositories"
+	"zatrano/utils"
+
+	"go.uber.org/zap"
+)
+
+const (
+	ErrUserSelfDelete        UserServiceError = "kendi hesabınızı silemezsiniz"
+	ErrUserSelfDeactivate    UserServiceError = "kendi hesabınızı pasif yapamazsınız"
+	ErrUserSelfDemote        UserServiceError = "kendi hesabınızı sistem kullanıcısı olmaktan çıkaramazsınız"
+	ErrLastSystemUser        UserServiceError = "bu işlem giriş yapabilen hiçbir sistem yöneticisi bırakmayacağı için yapılamaz"
+	ErrSystemUserCheckFailed UserServiceError = "sistem kullanıcıları denetlenemediği için işlem yapılmadı"
+)
+
+// isActiveAdmin, kullanıcının sisteme giriş yapabilen ve yerleşik sistem rolüne sahip olup olmadığını döner.
+func isActiveAdmin(user *models.User) bool {
+	return user.Status && !user.Pending && models.HasSystemRole(user.Roles)
+}
+
+// guardUpdate, güncellemenin korumalı bir kullanıcıyı değiştirmediğini ve işlemi yapanın kendi hesabını pasif
+// yapmadığını veya sistem tipinden ya da rolünden çıkarmadığını denetler. keepsSystemRole, güncellemeden sonra
+// kullanıcının yerleşik sistem rolüne sahip olup olmayacağıdır. Dönen bool, güncelleme bir aktif sistem
+// yöneticisini kaldırdığı için son yönetici denetiminin yazmayla aynı işlemde yapılması gerektiğini belirtir.
+func (s *UserService) guardUpdate(actorID uint, existing *models.User, status bool, userType models.UserType, keepsSystemRole bool) (bool, error) {
+	if existing.Protected && existing.ID != actorID {
+		return false, ErrUserProtected
+	}
+	if existing.ID == actorID {
+		if existing.Status && !status {
+			return false, ErrUserSelfDeactivate
+		}
+		if existing.Type == models.System && userType != models.System {
+			return false, ErrUserSelfDemote
+		}
+		if models.HasSystemRole(existing.Roles) && !keepsSystemRole {
+			return false, ErrUserSelfDemote
+		}
+	}
+	return isActiveAdmin(existing) && (!status || !keepsSystemRole), nil
+}
+
+// guardDelete, guardUpdate'in silme için olan karşılığıdır.
+func (s *UserService) guardDelete(actorID uint, existing *models.User) (bool, error) {
+	if existing.Protected {
+		return false, ErrUserProtected
+	}
+	if existing.ID == actorID {
+		return false, ErrUserSelfDelete
+	}
+	return isActiveAdmin(existing), nil
+}
+
+// lastAdminError, yazma işlemi son aktif sistem yöneticisi denetimine takıldıysa ErrLastSystemUser, değilse nil döner.
+func lastAdminError(id uint, err error) error {
+	if !errors.Is(err, repositories.ErrNoActiveAdmin) {
+		return nil
+	}
+	utils.Log.Warn("Son aktif sistem yöneticisini kaldıracak işlem engellendi", zap.Uint("user_id", id))
+	return ErrLastSystemUser
+}
+
+// ensureAdminRemains, verilen kullanıcılar yönetici olmaktan çıktığında en az bir aktif sistem yöneticisi
+// kalıp kalmadığını denetler. Sayım yapılamazsa işlem güvenli tarafta kalınarak reddedilir.
+func (s *UserService) ensureAdminRemains(removedIDs ...uint) error {
+	remaining, err := s.repo.CountActiveAdmins(removedIDs...)
+	if err != nil {
+		utils.Log.Error("Aktif sistem yöneticileri sayılamadı", zap.Uints("user_ids", removedIDs), zap.Error(err))
+		return ErrSystemUserCheckFailed
+	}
+	if remaining == 0 {
+		utils.Log.Warn("Son aktif sistem yöneticisini kaldıracak işlem engellendi", zap.Uints("user_ids", removedIDs))
+		return ErrLastSystemUser
+	}
+	return nil
+}
+
+// keepAdmin, toplu işlemde uygun bulunan kullanıcılar yönetici olmaktan çıktığında hiç aktif sistem
+// yöneticisi kalmayacaksa, bir tanesini ErrLastSystemUser nedeniyle atlayarak kalan listeyi döner.
+func (s *UserService) keepAdmin(found map[uint]*models.User, eligible []uint, result *BulkResult) ([]uint, error) {
+	var affected []uint
+	for _, id := range eligible {
+		if isActiveAdmin(found[id]) {
+			affected = append(affected, id)
+		}
+	}
+	if len(affected) == 0 {
+		return eligible, nil
+	}
+
+	switch err := s.ensureAdminRemains(affected...); err {
+	case nil:
+		return eligible, nil
+	case ErrLastSystemUser:
+	default:
+		return nil, err
+	}
+
+	kept := affected[0]
+	result.skip(kept, found[kept].Account, ErrLastSystemUser)
+	remaining := make([]uint, 0, len(eligible)-1)
+	for _, id := range eligible {
+		if id != kept {
+			remaining = append(remaining, id)
+		}
+	}
+	return remaining, nil
+}
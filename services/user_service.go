@@ -1,8 +1,15 @@
 package services
 
 import (
+	"io"
+	"strings"
+	"time"
+
+	"zatrano/cache"
+	"zatrano/configs"
 	"zatrano/models"
 	"zatrano/repositories"
+	"zatrano/storage"
 	"zatrano/utils"
 
 	"go.uber.org/zap"
@@ -23,26 +30,79 @@ const (
 	ErrUserUpdateFailed        UserServiceError = "kullanıcı veritabanında güncellenemedi"
 	ErrUserDeletionFailed      UserServiceError = "kullanıcı silinirken bir veritabanı hatası oluştu"
 	ErrPasswordRequired        UserServiceError = "şifre alanı boş olamaz"
+	ErrUserOutOfScope          UserServiceError = "bu kullanıcı üzerinde işlem yetkiniz yok"
+	ErrUserScopeNoTeam         UserServiceError = "bir takıma atanmadığınız için kullanıcı yönetemezsiniz"
+	ErrUserNameRequired        UserServiceError = "ad soyad alanı boş olamaz"
+	ErrUserAccountRequired     UserServiceError = "hesap adı boş olamaz"
+	ErrUserAccountTaken        UserServiceError = "bu hesap adı başka bir kullanıcı tarafından kullanılıyor"
+	ErrUserRestoreConflict     UserServiceError = "aynı hesap adıyla aktif bir kullanıcı bulunduğu için geri yüklenemedi"
+	ErrUserRestoreFailed       UserServiceError = "kullanıcı geri yüklenirken bir veritabanı hatası oluştu"
+	ErrUserPurgeFailed         UserServiceError = "kullanıcı kalıcı olarak silinirken bir veritabanı hatası oluştu"
 )
 
+// UserScope, işlemi yapan kullanıcının görebileceği ve yönetebileceği kullanıcı kümesini tanımlar.
+// AllTeams false ise yalnızca TeamID'deki takımın üyeleri yönetilebilir.
+type UserScope struct {
+	AllTeams bool
+	TeamID   *uint
+}
+
 type IUserService interface {
-	GetAllUsersPaginated(params utils.ListParams) (*utils.PaginatedResult, error)
+	GetAllUsersPaginated(params utils.ListParams, filter utils.UserFilter, scope UserScope) (*utils.PaginatedResult, error)
+	GetUsersByCursor(params utils.ListParams, filter utils.UserFilter, scope UserScope) (*utils.CursorPaginatedResult, error)
+	UsesCursorPaging(params utils.ListParams) bool
+	ResolveScope(actorID uint) UserScope
+	CanManage(scope UserScope, target *models.User) bool
 	GetUserByID(id uint) (*models.User, error)
-	CreateUser(user *models.User) error
-	UpdateUser(id uint, userData *models.User) error
-	DeleteUser(id uint) error
+	ValidateNewUser(user *models.User) error
+	CreateUser(actorID uint, user *models.User) error
+	InviteUser(user *models.User, invitedBy uint) error
+	UpdateUser(actorID, id uint, userData *models.User) error
+	DeleteUser(actorID, id uint) error
+	UnlockUser(id uint) error
+	LogoutEverywhere(id uint) (int64, error)
 	GetUserCount() (int64, error)
+	GetCacheStats() cache.Stats
+	GetDeletedUsersPaginated(params utils.ListParams, filter utils.UserFilter, scope UserScope) (*utils.PaginatedResult, error)
+	GetDeletedUserByID(id uint) (*models.User, error)
+	RestoreUser(id uint) error
+	PurgeUser(id uint) error
+	PurgeExpiredDeletedUsers(retentionDays int) (int64, error)
+	GetTrashRetentionDays() int
+	BulkSetStatus(actorID uint, scope UserScope, ids []uint, status bool) (*BulkResult, error)
+	BulkSetType(actorID uint, scope UserScope, ids []uint, userType models.UserType) (*BulkResult, error)
+	BulkDelete(actorID uint, scope UserScope, ids []uint) (*BulkResult, error)
+	PreviewImport(rows []ImportRow, scope UserScope) (*ImportReport, error)
+	CommitImport(actorID uint, rows []ImportRow, scope UserScope) (*ImportResult, error)
+	ExportUsers(w io.Writer, format string, params utils.ListParams, filter utils.UserFilter, scope UserScope) error
+	UpdateContactInfo(id uint, email, phone string) error
+	SetAvatar(id uint, upload io.Reader) error
+	RemoveAvatar(id uint) error
 }
 
+const userPurgeBatchSize = 100
+
 type UserService struct {
-	repo repositories.IUserRepository
+	repo        repositories.IUserRepository
+	sessions    ISessionService
+	passwords   IPasswordPolicyService
+	roles       IRoleService
+	files       storage.Storage
+	invitations IUserInvitationService
 }
 
 func NewUserService() IUserService {
-	return &UserService{repo: repositories.NewUserRepository()}
+	return &UserService{
+		repo:        repositories.NewUserRepository(),
+		sessions:    NewSessionService(),
+		passwords:   NewPasswordPolicyService(),
+		roles:       NewRoleService(),
+		files:       configs.GetStorage(),
+		invitations: NewUserInvitationService(),
+	}
 }
 
-func (s *UserService) GetAllUsersPaginated(params utils.ListParams) (*utils.PaginatedResult, error) {
+func (s *UserService) GetAllUsersPaginated(params utils.ListParams, filter utils.UserFilter, scope UserScope) (*utils.PaginatedResult, error) {
 	if params.Page <= 0 {
 		params.Page = utils.DefaultPage
 	}
@@ -63,7 +123,9 @@ func (s *UserService) GetAllUsersPaginated(params utils.ListParams) (*utils.Pagi
 		params.OrderBy = utils.DefaultOrderBy
 	}
 
-	users, totalCount, err := s.repo.FindAndPaginate(params)
+	scope.restrict(&filter)
+
+	users, totalCount, err := s.repo.FindAndPaginate(params, filter)
 	if err != nil {
 		return nil, err
 	}
@@ -83,6 +145,97 @@ func (s *UserService) GetAllUsersPaginated(params utils.ListParams) (*utils.Pagi
 	return result, nil
 }
 
+// GetUsersByCursor, kullanıcıları keyset (imleç) sayfalamayla listeler. Derin sayfalarda OFFSET maliyeti
+// oluşmaz; büyük tablolarda toplam, yapılandırmaya göre Postgres istatistiklerinden tahmin edilir.
+func (s *UserService) GetUsersByCursor(params utils.ListParams, filter utils.UserFilter, scope UserScope) (*utils.CursorPaginatedResult, error) {
+	if params.PerPage <= 0 || params.PerPage > utils.MaxPerPage {
+		params.PerPage = utils.DefaultPerPage
+	}
+	if params.SortBy == "" {
+		params.SortBy = utils.DefaultSortBy
+	}
+	if params.OrderBy == "" {
+		params.OrderBy = utils.DefaultOrderBy
+	}
+
+	scope.restrict(&filter)
+
+	users, next, prev, err := s.repo.FindByCursor(params, filter)
+	if err != nil {
+		return nil, err
+	}
+	total, estimated, err := s.repo.CountFiltered(params, filter, configs.GetUserListConfig().EstimateCountAbove)
+	if err != nil {
+		return nil, err
+	}
+
+	return &utils.CursorPaginatedResult{
+		Data: users,
+		Meta: utils.CursorMeta{
+			PerPage:        params.PerPage,
+			TotalItems:     total,
+			TotalEstimated: estimated,
+			NextCursor:     next,
+			PrevCursor:     prev,
+		},
+	}, nil
+}
+
+// UsesCursorPaging, isteğin imleçli sayfalamayla karşılanıp karşılanmayacağını belirtir. İstekte imleç
+// veya "paging" verilmemişse USER_LIST_PAGINATION ayarı geçerlidir. Benzerlik sıralaması imleçle
+// sayfalanamadığından her zaman sayfa numarasıyla listelenir.
+func (s *UserService) UsesCursorPaging(params utils.ListParams) bool {
+	switch {
+	case params.SortBy == utils.SortByRelevance && params.Name != "":
+		return false
+	case params.Cursor != "":
+		return true
+	case params.Paging != "":
+		return params.Paging == utils.PagingCursor
+	}
+	return configs.GetUserListConfig().Paging == utils.PagingCursor
+}
+
+// restrict, takımla sınırlı kapsamlarda listeyi yalnızca kendi takımına indirir; takımı olmayan
+// kullanıcılar için hiçbir kayıtla eşleşmeyen bir takım filtresi koyar.
+func (scope UserScope) restrict(filter *utils.UserFilter) {
+	if scope.AllTeams {
+		return
+	}
+	var noTeam uint
+	filter.ScopeTeamID = &noTeam
+	if scope.TeamID != nil {
+		filter.ScopeTeamID = scope.TeamID
+	}
+}
+
+// ResolveScope, işlemi yapan kullanıcının izinlerine göre yönetebileceği kullanıcı kümesini döner.
+// Hata durumunda hiçbir kullanıcıya izin vermeyen kısıtlı kapsam döner.
+func (s *UserService) ResolveScope(actorID uint) UserScope {
+	if s.roles.HasPermission(actorID, models.PermUsersAllTeams) {
+		return UserScope{AllTeams: true}
+	}
+
+	actor, err := s.repo.FindByID(actorID)
+	if err != nil {
+		utils.Log.Warn("Kullanıcı kapsamı belirlenemedi", zap.Uint("actor_id", actorID), zap.Error(err))
+		return UserScope{}
+	}
+	return UserScope{TeamID: actor.TeamID}
+}
+
+// CanManage, kapsamın hedef kullanıcıyı kapsayıp kapsamadığını döner. Takımla sınırlı yöneticiler,
+// yetki yükseltmeyi önlemek için tüm takımları yönetebilen kullanıcılar üzerinde işlem yapamaz.
+func (s *UserService) CanManage(scope UserScope, target *models.User) bool {
+	if scope.AllTeams {
+		return true
+	}
+	if scope.TeamID == nil || !target.InTeam(*scope.TeamID) {
+		return false
+	}
+	return !s.roles.HasPermission(target.ID, models.PermUsersAllTeams)
+}
+
 func (s *UserService) GetUserByID(id uint) (*models.User, error) {
 	user, err := s.repo.FindByID(id)
 	if err != nil {
@@ -96,22 +249,116 @@ func (s *UserService) GetUserByID(id uint) (*models.User, error) {
 	return user, nil
 }
 
-func (s *UserService) CreateUser(user *models.User) error {
-	if user.Password == "" {
+// ValidateNewUser, yeni bir kullanıcının oluşturulmadan önce sağlaması gereken kuralları uygular.
+// Şifre politikası ihlallerinde *passwordpolicy.PolicyError döner.
+func (s *UserService) ValidateNewUser(user *models.User) error {
+	if strings.TrimSpace(user.Name) == "" {
+		return ErrUserNameRequired
+	}
+	if strings.TrimSpace(user.Account) == "" {
+		return ErrUserAccountRequired
+	}
+	if user.Type != models.System && user.Type != models.Panel {
+		return models.ErrInvalidUserType
+	}
+	if user.Password == "" && !user.Pending {
 		return ErrPasswordRequired
 	}
+	email, phone, err := NormalizeContact(user.Email, user.Phone)
+	if err != nil {
+		return err
+	}
+	user.Email, user.Phone = email, phone
+	if user.Pending && user.Email == "" {
+		return ErrInvitationEmailRequired
+	}
+
+	taken, err := s.repo.ExistsActiveAccount(user.Account)
+	if err != nil {
+		utils.Log.Error("Hesap adı kullanımı kontrol edilemedi", zap.String("account", user.Account), zap.Error(err))
+		return ErrUserCreationFailed
+	}
+	if taken {
+		return ErrUserAccountTaken
+	}
+
+	if user.Pending {
+		return nil
+	}
+	return s.passwords.Validate(user, user.Password)
+}
+
+func (s *UserService) CreateUser(actorID uint, user *models.User) error {
+	if err := s.ValidateNewUser(user); err != nil {
+		return err
+	}
+	// Yönetici tarafından belirlenen şifre ilk girişte kullanıcıya değiştirilir.
+	user.MustChangePassword = true
 
 	if err := user.SetPassword(user.Password); err != nil {
 		utils.Log.Error("Kullanıcı oluşturma: Şifre ayarlanamadı/hashlenemedi", zap.String("account", user.Account), zap.Error(err))
 		return ErrPasswordHashingFailed
 	}
 
+	if err := s.insert(actorID, user); err != nil {
+		return err
+	}
+	s.passwords.RecordPassword(user.ID, user.Type, user.Password)
+
+	utils.SLog.Infof("Kullanıcı başarıyla oluşturuldu: %s (ID: %d)", user.Account, user.ID)
+	return nil
+}
+
+// InviteUser, kullanıcıyı şifresi olmadan bekleyen durumda oluşturur ve e-posta adresine şifresini
+// belirleyebileceği bir davet bağlantısı gönderir. Kullanıcı oluşturulup davet gönderilemezse
+// ErrInvitationFailed döner; davet listeden yeniden gönderilebilir.
+func (s *UserService) InviteUser(user *models.User, invitedBy uint) error {
+	user.Pending = true
+	user.MustChangePassword = false
+	if err := s.ValidateNewUser(user); err != nil {
+		return err
+	}
+
+	// Kimsenin bilmediği rastgele bir değer hashlenerek saklanır; davet kabul edilene kadar giriş yapılamaz.
+	placeholder, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		utils.Log.Error("Davet: Geçici parola üretilemedi", zap.String("account", user.Account), zap.Error(err))
+		return ErrPasswordHashingFailed
+	}
+	user.Password = placeholder
+
+	if err := s.insert(invitedBy, user); err != nil {
+		return err
+	}
+	utils.SLog.Infof("Kullanıcı davetle oluşturuldu: %s (ID: %d)", user.Account, user.ID)
+
+	if err := s.invitations.Send(user.ID, invitedBy); err != nil {
+		utils.Log.Warn("Kullanıcı oluşturuldu ancak davet gönderilemedi", zap.Uint("user_id", user.ID), zap.Error(err))
+		return ErrInvitationFailed
+	}
+	return nil
+}
+
+// insert, doğrulanmış kullanıcıyı kaydeder ve actorID'nin atayabileceği rolleri atar.
+func (s *UserService) insert(actorID uint, user *models.User) error {
 	utils.Log.Info("Kullanıcı oluşturuluyor...",
 		zap.String("account", user.Account),
 		zap.Any("type", user.Type),
 	)
 
-	err := s.repo.Create(user)
+	// Roller formdan yalnızca ID ile gelir; kullanıcıyla birlikte yazılmadan önce kayıtlı rollere çözülür.
+	roleIDs := roleIDsOf(user.Roles)
+	if err := s.roles.AuthorizeRoleChange(actorID, user.Type, nil, roleIDs, len(roleIDs) > 0); err != nil {
+		return err
+	}
+	roles, err := s.roles.ResolveRoles(user.Type, roleIDs)
+	if err != nil {
+		utils.Log.Error("Kullanıcı oluşturma: Roller okunamadı", zap.String("account", user.Account), zap.Error(err))
+		return ErrRoleAssignFailed
+	}
+	user.Roles = roles
+
+	err = s.repo.Create(user)
 	if err != nil {
 		utils.Log.Error("Kullanıcı oluşturulurken veritabanı hatası",
 			zap.String("account", user.Account),
@@ -123,13 +370,13 @@ func (s *UserService) CreateUser(user *models.User) error {
 		}
 		return ErrUserCreationFailed
 	}
-
-	utils.SLog.Infof("Kullanıcı başarıyla oluşturuldu: %s (ID: %d)", user.Account, user.ID)
 	return nil
 }
 
-func (s *UserService) UpdateUser(id uint, userData *models.User) error {
-	_, err := s.repo.FindByID(id)
+// UpdateUser, actorID'nin yaptığı güncellemeyi uygular; guardUpdate kurallarını ihlal eden değişiklikler
+// ErrUserProtected, ErrUserSelfDeactivate, ErrUserSelfDemote veya ErrLastSystemUser ile reddedilir.
+func (s *UserService) UpdateUser(actorID, id uint, userData *models.User) error {
+	existing, err := s.repo.FindByID(id)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			utils.Log.Warn("Kullanıcı güncellenemedi: Kullanıcı bulunamadı (ön kontrol)", zap.Uint("user_id", id))
@@ -139,32 +386,81 @@ func (s *UserService) UpdateUser(id uint, userData *models.User) error {
 		return err
 	}
 
+	// Tip değişikliğinde roller elle seçilmemişse yeni tipin yerleşik rolü atanır.
+	rolesChanged := userData.Roles != nil || existing.Type != userData.Type
+	nextRoles := existing.Roles
+	if rolesChanged {
+		if nextRoles, err = s.roles.ResolveRoles(userData.Type, roleIDsOf(userData.Roles)); err != nil {
+			utils.Log.Error("Kullanıcı güncelleme: Atanacak roller okunamadı", zap.Uint("user_id", id), zap.Error(err))
+			return ErrRoleAssignFailed
+		}
+	}
+
+	keepAdmin, err := s.guardUpdate(actorID, existing, userData.Status, userData.Type, models.HasSystemRole(nextRoles))
+	if err != nil {
+		return err
+	}
+	if rolesChanged {
+		err := s.roles.AuthorizeRoleChange(actorID, userData.Type, roleIDsOf(existing.Roles), roleIDsOf(userData.Roles), userData.Roles != nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	email, phone, err := NormalizeContact(userData.Email, userData.Phone)
+	if err != nil {
+		return err
+	}
+
 	updateData := map[string]interface{}{
 		"name":    userData.Name,
 		"account": userData.Account,
+		"email":   email,
+		"phone":   phone,
 		"status":  userData.Status,
 		"type":    userData.Type,
+		"team_id": userData.TeamID,
 	}
 
 	passwordUpdated := false
 	if userData.Password != "" {
-		tempUserForHash := models.User{}
+		candidate := &models.User{Type: userData.Type, Account: userData.Account, Password: existing.Password}
+		candidate.ID = id
+		if err := s.passwords.Validate(candidate, userData.Password); err != nil {
+			return err
+		}
+
+		tempUserForHash := models.User{Type: userData.Type, Account: userData.Account}
 		if err := tempUserForHash.SetPassword(userData.Password); err != nil {
 			utils.Log.Error("Kullanıcı güncelleme: Şifre ayarlanamadı/hashlenemedi", zap.Uint("user_id", id), zap.Error(err))
 			return ErrPasswordUpdateFailed
 		}
 		updateData["password"] = tempUserForHash.Password
+		updateData["credentials_changed_at"] = time.Now().UTC()
+		updateData["must_change_password"] = true
 		passwordUpdated = true
 	}
 
+	deactivated := existing.Status && !userData.Status
+	if passwordUpdated || deactivated || existing.Type != userData.Type {
+		updateData["session_version"] = models.NextSessionVersion()
+	}
+
 	utils.Log.Info("Kullanıcı güncelleniyor (map ile)...",
 		zap.Uint("user_id", id),
 		zap.Bool("password_updated", passwordUpdated),
 		zap.String("type", string(userData.Type)),
 	)
 
-	err = s.repo.Update(id, updateData)
+	var roles []models.Role
+	if rolesChanged {
+		roles = nextRoles
+	}
+	err = s.repo.UpdateWithRoles(id, updateData, roles, keepAdmin)
 	if err != nil {
+		if lastErr := lastAdminError(id, err); lastErr != nil {
+			return lastErr
+		}
 		utils.Log.Error("Kullanıcı güncellenirken veritabanı hatası (Update)",
 			zap.Uint("user_id", id),
 			zap.Error(err),
@@ -179,13 +475,42 @@ func (s *UserService) UpdateUser(id uint, userData *models.User) error {
 		return ErrUserUpdateFailed
 	}
 
+	if passwordUpdated {
+		s.passwords.RecordPassword(id, userData.Type, updateData["password"].(string))
+	}
+
+	if deactivated || passwordUpdated {
+		if _, err := s.LogoutEverywhere(id); err != nil {
+			utils.Log.Warn("Kullanıcının mevcut oturumları sonlandırılamadı", zap.Uint("user_id", id), zap.Error(err))
+		}
+	}
+
 	utils.SLog.Infof("Kullanıcı başarıyla güncellendi (map ile): ID %d, Hesap: %s", id, userData.Account)
 	return nil
 }
 
-func (s *UserService) DeleteUser(id uint) error {
-	err := s.repo.Delete(id)
+// DeleteUser, korumalı kullanıcıların, işlemi yapanın kendi hesabının ve son aktif sistem kullanıcısının
+// silinmesini reddeder.
+func (s *UserService) DeleteUser(actorID, id uint) error {
+	existing, err := s.repo.FindByID(id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			utils.Log.Warn("Kullanıcı silinemedi: Kullanıcı bulunamadı", zap.Uint("user_id", id))
+			return ErrUserServiceUserNotFound
+		}
+		utils.Log.Error("Kullanıcı silinemedi: Kullanıcı aranırken hata", zap.Uint("user_id", id), zap.Error(err))
+		return ErrUserDeletionFailed
+	}
+	keepAdmin, err := s.guardDelete(actorID, existing)
+	if err != nil {
+		return err
+	}
+
+	err = s.repo.Delete(id, keepAdmin)
 	if err != nil {
+		if lastErr := lastAdminError(id, err); lastErr != nil {
+			return lastErr
+		}
 		if err == gorm.ErrRecordNotFound {
 			utils.Log.Warn("Kullanıcı silinemedi: Kullanıcı bulunamadı", zap.Uint("user_id", id))
 			return ErrUserServiceUserNotFound
@@ -193,10 +518,165 @@ func (s *UserService) DeleteUser(id uint) error {
 		utils.Log.Error("Kullanıcı silinirken hata oluştu (Delete)", zap.Uint("user_id", id), zap.Error(err))
 		return ErrUserDeletionFailed
 	}
+	if _, err := s.sessions.RevokeAll(id); err != nil {
+		utils.Log.Warn("Silinen kullanıcının oturumları sonlandırılamadı", zap.Uint("user_id", id), zap.Error(err))
+	}
 	utils.SLog.Infof("Kullanıcı başarıyla silindi: ID %d", id)
 	return nil
 }
 
+// GetDeletedUsersPaginated, çöp kutusundaki kullanıcıları kullanıcı listesiyle aynı kapsam kurallarıyla listeler.
+func (s *UserService) GetDeletedUsersPaginated(params utils.ListParams, filter utils.UserFilter, scope UserScope) (*utils.PaginatedResult, error) {
+	filter.OnlyDeleted = true
+	return s.GetAllUsersPaginated(params, filter, scope)
+}
+
+func (s *UserService) GetDeletedUserByID(id uint) (*models.User, error) {
+	user, err := s.repo.FindDeletedByID(id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, ErrUserServiceUserNotFound
+		}
+		utils.Log.Error("Silinmiş kullanıcı alınırken hata", zap.Uint("user_id", id), zap.Error(err))
+		return nil, err
+	}
+	return user, nil
+}
+
+func (s *UserService) RestoreUser(id uint) error {
+	user, err := s.GetDeletedUserByID(id)
+	if err != nil {
+		return err
+	}
+
+	taken, err := s.repo.ExistsActiveAccount(user.Account)
+	if err != nil {
+		utils.Log.Error("Kullanıcı geri yüklenemedi: Hesap adı kontrol edilemedi", zap.Uint("user_id", id), zap.Error(err))
+		return ErrUserRestoreFailed
+	}
+	if taken {
+		utils.Log.Warn("Kullanıcı geri yüklenemedi: Hesap adı kullanımda", zap.Uint("user_id", id), zap.String("account", user.Account))
+		return ErrUserRestoreConflict
+	}
+
+	if err := s.repo.Restore(id); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return ErrUserServiceUserNotFound
+		}
+		utils.Log.Error("Kullanıcı geri yüklenirken hata oluştu", zap.Uint("user_id", id), zap.Error(err))
+		return ErrUserRestoreFailed
+	}
+
+	utils.SLog.Infof("Kullanıcı geri yüklendi: ID %d", id)
+	return nil
+}
+
+// PurgeUser, yalnızca daha önce silinmiş bir kullanıcıyı kalıcı olarak siler.
+func (s *UserService) PurgeUser(id uint) error {
+	avatarKeys := s.repo.AvatarKeys(id)
+	purged, err := s.repo.Purge(id)
+	if err != nil {
+		utils.Log.Error("Kullanıcı kalıcı olarak silinirken hata oluştu", zap.Uint("user_id", id), zap.Error(err))
+		return ErrUserPurgeFailed
+	}
+	if purged == 0 {
+		return ErrUserServiceUserNotFound
+	}
+	s.deleteAvatarFiles(avatarKeys...)
+
+	utils.SLog.Infof("Kullanıcı kalıcı olarak silindi: ID %d", id)
+	return nil
+}
+
+// GetTrashRetentionDays, silinen kullanıcıların otomatik olarak kalıcı silinmeden önce tutulduğu gün sayısını döner; 0 otomatik silmenin kapalı olduğunu belirtir.
+func (s *UserService) GetTrashRetentionDays() int {
+	cfg := configs.GetUserTrashConfig()
+	if !cfg.AutoPurgeEnabled() {
+		return 0
+	}
+	return cfg.RetentionDays
+}
+
+// PurgeExpiredDeletedUsers, retentionDays günden uzun süredir çöp kutusunda olan kullanıcıları
+// küçük gruplar halinde kalıcı olarak siler ve silinen kayıt sayısını döner.
+func (s *UserService) PurgeExpiredDeletedUsers(retentionDays int) (int64, error) {
+	if retentionDays <= 0 {
+		return 0, nil
+	}
+	cutoff := time.Now().UTC().AddDate(0, 0, -retentionDays)
+
+	var total int64
+	for {
+		ids, err := s.repo.FindDeletedIDsBefore(cutoff, userPurgeBatchSize)
+		if err != nil {
+			utils.Log.Error("Süresi dolan silinmiş kullanıcılar alınamadı", zap.Error(err))
+			return total, ErrUserPurgeFailed
+		}
+		if len(ids) == 0 {
+			return total, nil
+		}
+
+		avatarKeys := s.repo.AvatarKeys(ids...)
+		purged, err := s.repo.Purge(ids...)
+		if err != nil {
+			utils.Log.Error("Süresi dolan silinmiş kullanıcılar kalıcı olarak silinemedi", zap.Error(err))
+			return total, ErrUserPurgeFailed
+		}
+		s.deleteAvatarFiles(avatarKeys...)
+		total += purged
+		if len(ids) < userPurgeBatchSize {
+			return total, nil
+		}
+	}
+}
+
+func (s *UserService) UnlockUser(id uint) error {
+	if _, err := s.repo.FindByID(id); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			utils.Log.Warn("Kullanıcı kilidi açılamadı: Kullanıcı bulunamadı", zap.Uint("user_id", id))
+			return ErrUserServiceUserNotFound
+		}
+		utils.Log.Error("Kullanıcı kilidi açılamadı: Kullanıcı aranırken hata", zap.Uint("user_id", id), zap.Error(err))
+		return err
+	}
+
+	err := s.repo.Update(id, map[string]interface{}{
+		"failed_login_attempts": 0,
+		"last_failed_login_at":  nil,
+		"locked_until":          nil,
+	})
+	if err != nil {
+		utils.Log.Error("Kullanıcı kilidi açılırken veritabanı hatası", zap.Uint("user_id", id), zap.Error(err))
+		return ErrUserUpdateFailed
+	}
+
+	utils.SLog.Infof("Kullanıcı kilidi açıldı: ID %d", id)
+	return nil
+}
+
+func (s *UserService) LogoutEverywhere(id uint) (int64, error) {
+	if _, err := s.repo.FindByID(id); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return 0, ErrUserServiceUserNotFound
+		}
+		return 0, err
+	}
+
+	count, err := s.sessions.RevokeAll(id)
+	if err != nil {
+		utils.Log.Error("Kullanıcının oturumları sonlandırılamadı", zap.Uint("user_id", id), zap.Error(err))
+		return 0, err
+	}
+
+	utils.SLog.Infof("Kullanıcının tüm oturumları sonlandırıldı: ID %d (%d oturum)", id, count)
+	return count, nil
+}
+
+// GetCacheStats, ID ile yapılan kullanıcı okumalarını karşılayan önbelleğin istatistiklerini döner.
+func (s *UserService) GetCacheStats() cache.Stats {
+	return repositories.UserCacheStats()
+}
+
 func (s *UserService) GetUserCount() (int64, error) {
 	count, err := s.repo.Count()
 	if err != nil {
@@ -206,4 +686,12 @@ func (s *UserService) GetUserCount() (int64, error) {
 	return count, nil
 }
 
+func roleIDsOf(roles []models.Role) []uint {
+	ids := make([]uint, 0, len(roles))
+	for _, role := range roles {
+		ids = append(ids, role.ID)
+	}
+	return ids
+}
+
 var _ IUserService = (*UserService)(nil)
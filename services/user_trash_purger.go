@@ -0,0 +1,57 @@
+package services
+
+import (
+	"sync"
+	"time"
+
+	"zatrano/configs"
+	"zatrano/utils"
+
+	"go.uber.org/zap"
+)
+
+// StartUserTrashPurger, saklama süresi dolan silinmiş kullanıcıları düzenli aralıklarla kalıcı olarak
+// siler. Dönen fonksiyon döngüyü durdurur; otomatik silme kapalıysa hiçbir şey başlatılmaz.
+func StartUserTrashPurger() (stop func()) {
+	cfg := configs.GetUserTrashConfig()
+	if !cfg.AutoPurgeEnabled() {
+		utils.SLog.Info("Silinen kullanıcıların otomatik temizliği kapalı.")
+		return func() {}
+	}
+
+	userService := NewUserService()
+	done := make(chan struct{})
+	var once sync.Once
+
+	purge := func() {
+		purged, err := userService.PurgeExpiredDeletedUsers(cfg.RetentionDays)
+		if err != nil {
+			utils.Log.Error("Silinen kullanıcılar otomatik olarak temizlenemedi", zap.Error(err))
+			return
+		}
+		if purged > 0 {
+			utils.Log.Info("Saklama süresi dolan silinmiş kullanıcılar kalıcı olarak silindi",
+				zap.Int64("purged", purged), zap.Int("retention_days", cfg.RetentionDays))
+		}
+	}
+
+	go func() {
+		ticker := time.NewTicker(cfg.PurgeInterval)
+		defer ticker.Stop()
+
+		purge()
+		for {
+			select {
+			case <-ticker.C:
+				purge()
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	utils.SLog.Infof("Silinen kullanıcılar %d gün sonra otomatik olarak temizlenecek.", cfg.RetentionDays)
+	return func() {
+		once.Do(func() { close(done) })
+	}
+}
@@ -0,0 +1,146 @@
+package services
+
+import (
+	"zatrano/models"
+	"zatrano/passwordpolicy"
+	"zatrano/repositories"
+	"zatrano/utils"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	ErrPolicyInvalidUserType ServiceError = "geçersiz kullanıcı tipi"
+	ErrPolicyUpdateFailed    ServiceError = "güvenlik politikası güncellenemedi"
+	ErrPolicyInvalidMinLen   ServiceError = "en kısa şifre uzunluğu 4 ile 72 arasında olmalıdır"
+	ErrPolicyInvalidHistory  ServiceError = "şifre geçmişi sayısı 0 ile 24 arasında olmalıdır"
+	ErrPolicyInvalidMaxAge   ServiceError = "şifre geçerlilik süresi 0 ile 3650 gün arasında olmalıdır"
+)
+
+type IUserTypePolicyService interface {
+	GetPolicies() ([]models.UserTypePolicy, error)
+	GetPolicy(userType models.UserType) models.UserTypePolicy
+	SetTwoFactorRequired(userType models.UserType, required bool) error
+	UpdatePasswordPolicy(userType models.UserType, passwordPolicy passwordpolicy.Policy) error
+}
+
+type UserTypePolicyService struct {
+	repo repositories.IUserTypePolicyRepository
+}
+
+func NewUserTypePolicyService() IUserTypePolicyService {
+	return &UserTypePolicyService{repo: repositories.NewUserTypePolicyRepository()}
+}
+
+// GetPolicies, kayıtlı olmayan tipler için varsayılan politikaları da içeren tam listeyi döner.
+func (s *UserTypePolicyService) GetPolicies() ([]models.UserTypePolicy, error) {
+	stored, err := s.repo.FindAll()
+	if err != nil {
+		utils.Log.Error("Kullanıcı tipi politikaları alınamadı", zap.Error(err))
+		return nil, err
+	}
+
+	byType := make(map[models.UserType]models.UserTypePolicy, len(stored))
+	for _, policy := range stored {
+		byType[policy.Type] = policy
+	}
+
+	policies := make([]models.UserTypePolicy, 0, len(models.AllUserTypes()))
+	for _, userType := range models.AllUserTypes() {
+		if policy, ok := byType[userType]; ok {
+			policies = append(policies, policy)
+			continue
+		}
+		policies = append(policies, models.DefaultUserTypePolicy(userType))
+	}
+	return policies, nil
+}
+
+func (s *UserTypePolicyService) GetPolicy(userType models.UserType) models.UserTypePolicy {
+	policy, err := s.repo.FindByType(userType)
+	if err != nil {
+		if err != gorm.ErrRecordNotFound {
+			utils.Log.Error("Kullanıcı tipi politikası alınamadı", zap.String("type", string(userType)), zap.Error(err))
+		}
+		return models.DefaultUserTypePolicy(userType)
+	}
+	return *policy
+}
+
+func (s *UserTypePolicyService) SetTwoFactorRequired(userType models.UserType, required bool) error {
+	policy, err := s.findOrNew(userType)
+	if err != nil {
+		return err
+	}
+
+	policy.RequireTwoFactor = required
+	if err := s.repo.Save(policy); err != nil {
+		utils.Log.Error("Kullanıcı tipi politikası kaydedilemedi", zap.String("type", string(userType)), zap.Error(err))
+		return ErrPolicyUpdateFailed
+	}
+
+	utils.Log.Info("İki adımlı doğrulama politikası güncellendi",
+		zap.String("type", string(userType)),
+		zap.Bool("require_two_factor", required),
+	)
+	return nil
+}
+
+func (s *UserTypePolicyService) UpdatePasswordPolicy(userType models.UserType, passwordPolicy passwordpolicy.Policy) error {
+	if passwordPolicy.MinLength < 4 || passwordPolicy.MinLength > 72 {
+		return ErrPolicyInvalidMinLen
+	}
+	if passwordPolicy.HistoryCount < 0 || passwordPolicy.HistoryCount > 24 {
+		return ErrPolicyInvalidHistory
+	}
+	if passwordPolicy.MaxAgeDays < 0 || passwordPolicy.MaxAgeDays > 3650 {
+		return ErrPolicyInvalidMaxAge
+	}
+
+	policy, err := s.findOrNew(userType)
+	if err != nil {
+		return err
+	}
+
+	policy.SetPasswordPolicy(passwordPolicy)
+	if err := s.repo.Save(policy); err != nil {
+		utils.Log.Error("Kullanıcı tipi politikası kaydedilemedi", zap.String("type", string(userType)), zap.Error(err))
+		return ErrPolicyUpdateFailed
+	}
+
+	utils.Log.Info("Şifre politikası güncellendi",
+		zap.String("type", string(userType)),
+		zap.Int("min_length", passwordPolicy.MinLength),
+		zap.Int("history_count", passwordPolicy.HistoryCount),
+		zap.Int("max_age_days", passwordPolicy.MaxAgeDays),
+	)
+	return nil
+}
+
+func (s *UserTypePolicyService) findOrNew(userType models.UserType) (*models.UserTypePolicy, error) {
+	valid := false
+	for _, t := range models.AllUserTypes() {
+		if t == userType {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return nil, ErrPolicyInvalidUserType
+	}
+
+	policy, err := s.repo.FindByType(userType)
+	if err == nil {
+		return policy, nil
+	}
+	if err != gorm.ErrRecordNotFound {
+		utils.Log.Error("Kullanıcı tipi politikası alınamadı", zap.String("type", string(userType)), zap.Error(err))
+		return nil, ErrPolicyUpdateFailed
+	}
+	policy = new(models.UserTypePolicy)
+	*policy = models.DefaultUserTypePolicy(userType)
+	return policy, nil
+}
+
+var _ IUserTypePolicyService = (*UserTypePolicyService)(nil)
@@ -0,0 +1,112 @@
+package sessionstore
+
+import (
+	"sync"
+	"time"
+
+	"zatrano/models"
+	"zatrano/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DatabaseStorage, oturum verilerini sessions tablosunda tutan fiber.Storage uygulamasıdır.
+type DatabaseStorage struct {
+	db        *gorm.DB
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func NewDatabaseStorage(db *gorm.DB, gcInterval time.Duration) *DatabaseStorage {
+	s := &DatabaseStorage{
+		db:   db,
+		done: make(chan struct{}),
+	}
+	if gcInterval > 0 {
+		go s.gcLoop(gcInterval)
+	}
+	return s
+}
+
+func (s *DatabaseStorage) Get(key string) ([]byte, error) {
+	if key == "" {
+		return nil, nil
+	}
+
+	var rows []models.Session
+	err := s.db.Where("id = ? AND (expires_at IS NULL OR expires_at > ?)", key, time.Now().UTC()).
+		Limit(1).
+		Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	return rows[0].Data, nil
+}
+
+func (s *DatabaseStorage) Set(key string, val []byte, exp time.Duration) error {
+	if key == "" || len(val) == 0 {
+		return nil
+	}
+
+	row := models.Session{ID: key, Data: val}
+	if exp > 0 {
+		expiresAt := time.Now().UTC().Add(exp)
+		row.ExpiresAt = &expiresAt
+	}
+
+	return s.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"data", "expires_at", "updated_at"}),
+	}).Create(&row).Error
+}
+
+func (s *DatabaseStorage) Delete(key string) error {
+	if key == "" {
+		return nil
+	}
+	return s.db.Where("id = ?", key).Delete(&models.Session{}).Error
+}
+
+func (s *DatabaseStorage) Reset() error {
+	return s.db.Where("1 = 1").Delete(&models.Session{}).Error
+}
+
+func (s *DatabaseStorage) Close() error {
+	s.closeOnce.Do(func() {
+		close(s.done)
+	})
+	return nil
+}
+
+func (s *DatabaseStorage) gcLoop(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.deleteExpired()
+		case <-s.done:
+			return
+		}
+	}
+}
+
+func (s *DatabaseStorage) deleteExpired() {
+	result := s.db.Where("expires_at IS NOT NULL AND expires_at <= ?", time.Now().UTC()).Delete(&models.Session{})
+	if result.Error != nil {
+		utils.Log.Error("Süresi dolmuş oturumlar temizlenemedi", zap.Error(result.Error))
+		return
+	}
+	if result.RowsAffected > 0 {
+		utils.Log.Info("Süresi dolmuş oturumlar temizlendi", zap.Int64("deleted", result.RowsAffected))
+	}
+}
+
+var _ fiber.Storage = (*DatabaseStorage)(nil)
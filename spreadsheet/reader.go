@@ -0,0 +1,91 @@
+package spreadsheet
+
+import (
+	"bytes"
+	"encoding/csv"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+type SpreadsheetError string
+
+func (e SpreadsheetError) Error() string {
+	return string(e)
+}
+
+const (
+	ErrUnsupportedFormat SpreadsheetError = "desteklenmeyen dosya biçimi, yalnızca CSV ve XLSX kabul edilir"
+	ErrUnreadableFile    SpreadsheetError = "dosya okunamadı veya bozuk"
+	ErrEmptyFile         SpreadsheetError = "dosyada okunacak satır bulunamadı"
+)
+
+// ReadRows, dosya adının uzantısına göre CSV veya XLSX içeriğini satır listesi olarak okur.
+// XLSX dosyalarında yalnızca ilk sayfa okunur. Satır numaraları raporlarda doğru kalsın diye
+// aradaki boş satırlar korunur; bunları atlamak çağırana aittir (bkz. IsBlankRow).
+func ReadRows(fileName string, r io.Reader) ([][]string, error) {
+	var (
+		rows [][]string
+		err  error
+	)
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".csv":
+		rows, err = readCSV(r)
+	case ".xlsx":
+		rows, err = readXLSX(r)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+	if err != nil {
+		return nil, ErrUnreadableFile
+	}
+
+	for _, row := range rows {
+		if !IsBlankRow(row) {
+			return rows, nil
+		}
+	}
+	return nil, ErrEmptyFile
+}
+
+func readCSV(r io.Reader) ([][]string, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	// Excel'in UTF-8 CSV çıktısındaki BOM ilk başlığın adını bozmasın.
+	content = bytes.TrimPrefix(content, []byte("\xef\xbb\xbf"))
+
+	reader := csv.NewReader(bytes.NewReader(content))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	if firstLine, _, _ := bytes.Cut(content, []byte("\n")); bytes.Count(firstLine, []byte(";")) > bytes.Count(firstLine, []byte(",")) {
+		reader.Comma = ';'
+	}
+	return reader.ReadAll()
+}
+
+func readXLSX(r io.Reader) ([][]string, error) {
+	file, err := excelize.OpenReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	sheets := file.GetSheetList()
+	if len(sheets) == 0 {
+		return nil, nil
+	}
+	return file.GetRows(sheets[0])
+}
+
+func IsBlankRow(row []string) bool {
+	for _, cell := range row {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
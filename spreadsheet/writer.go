@@ -0,0 +1,196 @@
+package spreadsheet
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+	FormatJSON = "json"
+)
+
+const ErrUnsupportedExportFormat SpreadsheetError = "desteklenmeyen dışa aktarma biçimi, csv, xlsx veya json kullanın"
+
+// Column, dışa aktarılan bir sütunun JSON anahtarını ve CSV/XLSX başlığını tanımlar.
+type Column struct {
+	Key   string
+	Label string
+}
+
+// Writer, satırları tek tek yazan bir dışa aktarma hedefidir. Close çağrılmadan çıktı tamamlanmaz.
+type Writer interface {
+	WriteRow(values []string) error
+	Close() error
+}
+
+// ContentType, biçim için HTTP Content-Type değerini döner.
+func ContentType(format string) string {
+	switch format {
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	case FormatXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	case FormatJSON:
+		return "application/json; charset=utf-8"
+	}
+	return "application/octet-stream"
+}
+
+func IsSupportedFormat(format string) bool {
+	return format == FormatCSV || format == FormatXLSX || format == FormatJSON
+}
+
+// NewWriter, verilen biçimde w'ye yazan bir Writer oluşturur ve başlığı yazar.
+// CSV ve JSON satırları geldikçe yazılır; XLSX satırları excelize'in akış yazıcısıyla geçici
+// dosyada biriktirilip Close sırasında w'ye aktarılır.
+func NewWriter(format string, w io.Writer, columns []Column) (Writer, error) {
+	switch strings.ToLower(format) {
+	case FormatCSV:
+		return newCSVWriter(w, columns)
+	case FormatXLSX:
+		return newXLSXWriter(w, columns)
+	case FormatJSON:
+		return newJSONWriter(w, columns)
+	}
+	return nil, ErrUnsupportedExportFormat
+}
+
+type csvWriter struct {
+	writer *csv.Writer
+}
+
+func newCSVWriter(w io.Writer, columns []Column) (*csvWriter, error) {
+	// BOM, Excel'in dosyayı UTF-8 olarak açması içindir.
+	if _, err := io.WriteString(w, "\xef\xbb\xbf"); err != nil {
+		return nil, err
+	}
+	writer := &csvWriter{writer: csv.NewWriter(w)}
+	return writer, writer.WriteRow(labels(columns))
+}
+
+func (c *csvWriter) WriteRow(values []string) error {
+	escaped := make([]string, len(values))
+	for i, value := range values {
+		escaped[i] = escapeFormula(value)
+	}
+	return c.writer.Write(escaped)
+}
+
+func (c *csvWriter) Close() error {
+	c.writer.Flush()
+	return c.writer.Error()
+}
+
+type xlsxWriter struct {
+	out    io.Writer
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	row    int
+}
+
+func newXLSXWriter(w io.Writer, columns []Column) (*xlsxWriter, error) {
+	file := excelize.NewFile()
+	stream, err := file.NewStreamWriter(file.GetSheetName(0))
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	writer := &xlsxWriter{out: w, file: file, stream: stream}
+	return writer, writer.WriteRow(labels(columns))
+}
+
+func (x *xlsxWriter) WriteRow(values []string) error {
+	x.row++
+	cells := make([]interface{}, len(values))
+	for i, value := range values {
+		cells[i] = value
+	}
+	cell, err := excelize.CoordinatesToCellName(1, x.row)
+	if err != nil {
+		return err
+	}
+	return x.stream.SetRow(cell, cells)
+}
+
+func (x *xlsxWriter) Close() error {
+	defer x.file.Close()
+	if err := x.stream.Flush(); err != nil {
+		return err
+	}
+	return x.file.Write(x.out)
+}
+
+type jsonWriter struct {
+	out     io.Writer
+	columns []Column
+	count   int
+}
+
+func newJSONWriter(w io.Writer, columns []Column) (*jsonWriter, error) {
+	if _, err := io.WriteString(w, "["); err != nil {
+		return nil, err
+	}
+	return &jsonWriter{out: w, columns: columns}, nil
+}
+
+// WriteRow, satırı sütun sırası korunarak bir JSON nesnesi olarak yazar.
+func (j *jsonWriter) WriteRow(values []string) error {
+	var buf bytes.Buffer
+	if j.count > 0 {
+		buf.WriteByte(',')
+	}
+	buf.WriteByte('{')
+	for i, column := range j.columns {
+		value := ""
+		if i < len(values) {
+			value = values[i]
+		}
+		key, err := json.Marshal(column.Key)
+		if err != nil {
+			return err
+		}
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(encoded)
+	}
+	buf.WriteByte('}')
+	j.count++
+	_, err := j.out.Write(buf.Bytes())
+	return err
+}
+
+func (j *jsonWriter) Close() error {
+	_, err := io.WriteString(j.out, "]\n")
+	return err
+}
+
+// escapeFormula, elektronik tabloların formül olarak yorumlayacağı CSV değerlerinin başına ' ekler. XLSX
+// hücreleri metin olarak yazıldığından formül olarak çalışmaz ve kaçırılmaz.
+func escapeFormula(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+func labels(columns []Column) []string {
+	values := make([]string, len(columns))
+	for i, column := range columns {
+		values[i] = column.Label
+	}
+	return values
+}
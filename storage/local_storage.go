@@ -0,0 +1,137 @@
+package storage
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/fs"
+	"mime"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LocalStorage, dosyaları yerel diskte root klasörü altında saklar. İçerik tipi ayrıca saklanmaz,
+// anahtarın uzantısından belirlenir. İmzalı adresler uygulamanın signedURLPrefix altındaki uç noktasını
+// gösterir ve secret ile HMAC-SHA256 imzalanır.
+type LocalStorage struct {
+	root            string
+	signedURLPrefix string
+	secret          []byte
+}
+
+func NewLocalStorage(root, signedURLPrefix string, secret []byte) *LocalStorage {
+	return &LocalStorage{root: root, signedURLPrefix: strings.TrimSuffix(signedURLPrefix, "/"), secret: secret}
+}
+
+func (s *LocalStorage) Put(key string, body io.Reader, size int64, contentType string) error {
+	target, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return err
+	}
+
+	// Yarım kalan yazmaların okunmaması için önce geçici dosyaya yazılır, sonra yerine taşınır.
+	tmp, err := os.CreateTemp(filepath.Dir(target), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, body); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), target)
+}
+
+func (s *LocalStorage) Open(key string) (*Object, error) {
+	target, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(target)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	contentType := mime.TypeByExtension(path.Ext(key))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	return &Object{Body: file, ContentType: contentType, Size: info.Size(), ModTime: info.ModTime()}, nil
+}
+
+// Delete, verilen anahtarları siler; zaten olmayan dosyalar hata sayılmaz.
+func (s *LocalStorage) Delete(keys ...string) error {
+	var firstErr error
+	for _, key := range keys {
+		target, err := s.path(key)
+		if err == nil {
+			err = os.Remove(target)
+		}
+		if err != nil && !errors.Is(err, fs.ErrNotExist) && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+func (s *LocalStorage) SignedURL(key string, ttl time.Duration) (string, error) {
+	cleaned, err := CleanKey(key)
+	if err != nil {
+		return "", err
+	}
+	expires := time.Now().Add(ttl).Unix()
+	query := url.Values{}
+	query.Set("expires", strconv.FormatInt(expires, 10))
+	query.Set("signature", s.sign(cleaned, expires))
+	return s.signedURLPrefix + "/" + (&url.URL{Path: cleaned}).EscapedPath() + "?" + query.Encode(), nil
+}
+
+// VerifySignedURL, SignedURL ile üretilmiş bir adresin süresinin dolmadığını ve imzasının doğru olduğunu denetler.
+func (s *LocalStorage) VerifySignedURL(key string, expires int64, signature string) bool {
+	cleaned, err := CleanKey(key)
+	if err != nil || time.Now().Unix() > expires {
+		return false
+	}
+	return hmac.Equal([]byte(signature), []byte(s.sign(cleaned, expires)))
+}
+
+func (s *LocalStorage) sign(key string, expires int64) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(key + "\n" + strconv.FormatInt(expires, 10)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *LocalStorage) path(key string) (string, error) {
+	cleaned, err := CleanKey(key)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(s.root, filepath.FromSlash(cleaned)), nil
+}
+
+var (
+	_ Storage     = (*LocalStorage)(nil)
+	_ URLVerifier = (*LocalStorage)(nil)
+)
@@ -0,0 +1,129 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// S3Config, S3 uyumlu bir nesne deposuna (AWS S3, MinIO, Ceph ...) bağlanmak için gereken ayarlardır.
+type S3Config struct {
+	Endpoint     string
+	AccessKey    string
+	SecretKey    string
+	Bucket       string
+	Region       string
+	UseSSL       bool
+	PathStyle    bool
+	CreateBucket bool
+}
+
+// S3Storage, dosyaları S3 uyumlu bir depoda tek bir bucket altında saklar. İmzalı adresleri depo
+// kendisi üretir ve doğrular.
+type S3Storage struct {
+	client *minio.Client
+	bucket string
+}
+
+func NewS3Storage(cfg S3Config) (*S3Storage, error) {
+	lookup := minio.BucketLookupAuto
+	if cfg.PathStyle {
+		lookup = minio.BucketLookupPath
+	}
+	client, err := minio.New(cfg.Endpoint, &minio.Options{
+		Creds:        credentials.NewStaticV4(cfg.AccessKey, cfg.SecretKey, ""),
+		Secure:       cfg.UseSSL,
+		Region:       cfg.Region,
+		BucketLookup: lookup,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s := &S3Storage{client: client, bucket: cfg.Bucket}
+	if cfg.CreateBucket {
+		if err := s.ensureBucket(cfg.Region); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+func (s *S3Storage) ensureBucket(region string) error {
+	ctx := context.Background()
+	exists, err := s.client.BucketExists(ctx, s.bucket)
+	if err != nil || exists {
+		return err
+	}
+	return s.client.MakeBucket(ctx, s.bucket, minio.MakeBucketOptions{Region: region})
+}
+
+func (s *S3Storage) Put(key string, body io.Reader, size int64, contentType string) error {
+	cleaned, err := CleanKey(key)
+	if err != nil {
+		return err
+	}
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	_, err = s.client.PutObject(context.Background(), s.bucket, cleaned, body, size, minio.PutObjectOptions{ContentType: contentType})
+	return err
+}
+
+func (s *S3Storage) Open(key string) (*Object, error) {
+	cleaned, err := CleanKey(key)
+	if err != nil {
+		return nil, err
+	}
+	object, err := s.client.GetObject(context.Background(), s.bucket, cleaned, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, s3Error(err)
+	}
+	// GetObject isteği tembel gönderir; dosyanın var olup olmadığı ilk Stat çağrısında anlaşılır.
+	info, err := object.Stat()
+	if err != nil {
+		object.Close()
+		return nil, s3Error(err)
+	}
+	return &Object{Body: object, ContentType: info.ContentType, Size: info.Size, ModTime: info.LastModified}, nil
+}
+
+// Delete, verilen anahtarları siler; S3 olmayan nesnelerin silinmesini zaten hata saymaz.
+func (s *S3Storage) Delete(keys ...string) error {
+	var firstErr error
+	for _, key := range keys {
+		cleaned, err := CleanKey(key)
+		if err == nil {
+			err = s.client.RemoveObject(context.Background(), s.bucket, cleaned, minio.RemoveObjectOptions{})
+		}
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+func (s *S3Storage) SignedURL(key string, ttl time.Duration) (string, error) {
+	cleaned, err := CleanKey(key)
+	if err != nil {
+		return "", err
+	}
+	signed, err := s.client.PresignedGetObject(context.Background(), s.bucket, cleaned, ttl, nil)
+	if err != nil {
+		return "", err
+	}
+	return signed.String(), nil
+}
+
+func s3Error(err error) error {
+	switch minio.ToErrorResponse(err).Code {
+	case "NoSuchKey", "NotFound":
+		return ErrNotFound
+	}
+	return err
+}
+
+var _ Storage = (*S3Storage)(nil)
@@ -0,0 +1,77 @@
+package storage
+
+import (
+	"io"
+	"path"
+	"strings"
+	"time"
+)
+
+type StorageError string
+
+func (e StorageError) Error() string {
+	return string(e)
+}
+
+const (
+	ErrNotFound   StorageError = "dosya bulunamadı"
+	ErrInvalidKey StorageError = "geçersiz dosya anahtarı"
+	ErrTooLarge   StorageError = "dosya okunabilecek boyuttan büyük"
+)
+
+// Object, depodan okunan bir dosyadır. Body okunduktan sonra kapatılmalıdır.
+type Object struct {
+	Body        io.ReadCloser
+	ContentType string
+	Size        int64
+	ModTime     time.Time
+}
+
+// Storage, yüklenen dosyaların (ör. profil resimleri) saklandığı arka uç arayüzüdür. Anahtarlar "/" ile
+// ayrılmış göreli yollardır (ör. "avatars/12/ab34_sm.png"). LocalStorage yerel diskte, S3Storage ise
+// S3 uyumlu bir nesne deposunda (AWS S3, MinIO ...) saklar.
+type Storage interface {
+	// Put, body'yi akış halinde yazar; size bilinmiyorsa -1 verilebilir.
+	Put(key string, body io.Reader, size int64, contentType string) error
+	// Open, dosyayı belleğe almadan akış olarak okumak için açar.
+	Open(key string) (*Object, error)
+	Delete(keys ...string) error
+	// SignedURL, dosyanın ttl süresince oturum gerektirmeden indirilebileceği imzalı bir adres üretir.
+	SignedURL(key string, ttl time.Duration) (string, error)
+}
+
+// URLVerifier, imzalı adresleri uygulamanın kendisi tarafından sunulan arka uçlarca uygulanır
+// (ör. LocalStorage); S3 gibi arka uçlarda imzayı depo doğrular.
+type URLVerifier interface {
+	VerifySignedURL(key string, expires int64, signature string) bool
+}
+
+// Get, küçük dosyaları tek seferde okumak için kısayoldur; maxBytes'ı aşan dosyalarda ErrTooLarge döner.
+func Get(s Storage, key string, maxBytes int64) ([]byte, error) {
+	object, err := s.Open(key)
+	if err != nil {
+		return nil, err
+	}
+	defer object.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(object.Body, maxBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > maxBytes {
+		return nil, ErrTooLarge
+	}
+	return data, nil
+}
+
+// CleanKey, anahtarı normalize eder ve depo kökünün dışına çıkan ya da mutlak anahtarları reddeder.
+func CleanKey(key string) (string, error) {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, `\`) {
+		return "", ErrInvalidKey
+	}
+	cleaned := path.Clean(key)
+	if cleaned == "." || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", ErrInvalidKey
+	}
+	return cleaned, nil
+}
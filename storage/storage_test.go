@@ -0,0 +1,266 @@
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeS3, path-style isteklerle tek bir bucket'a PUT/GET/HEAD/DELETE yapılabilen bellek içi S3 taklididir.
+type fakeS3 struct {
+	mu      sync.Mutex
+	bucket  string
+	objects map[string]fakeS3Object
+}
+
+type fakeS3Object struct {
+	body        []byte
+	contentType string
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	if bucket != f.bucket {
+		writeS3Error(w, http.StatusNotFound, "NoSuchBucket")
+		return
+	}
+	if key == "" {
+		// Bucket düzeyindeki istekler (ör. BucketExists) için bucket'ın var olduğunu söylemek yeterlidir.
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch r.Method {
+	case http.MethodPut:
+		body, err := readS3Payload(r)
+		if err != nil {
+			writeS3Error(w, http.StatusBadRequest, "IncompleteBody")
+			return
+		}
+		f.objects[key] = fakeS3Object{body: body, contentType: r.Header.Get("Content-Type")}
+		w.Header().Set("ETag", `"fake"`)
+		w.WriteHeader(http.StatusOK)
+	case http.MethodGet, http.MethodHead:
+		object, ok := f.objects[key]
+		if !ok {
+			writeS3Error(w, http.StatusNotFound, "NoSuchKey")
+			return
+		}
+		w.Header().Set("Content-Type", object.contentType)
+		w.Header().Set("Content-Length", strconv.Itoa(len(object.body)))
+		w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+		w.Header().Set("ETag", `"fake"`)
+		w.WriteHeader(http.StatusOK)
+		if r.Method == http.MethodGet {
+			w.Write(object.body)
+		}
+	case http.MethodDelete:
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// readS3Payload, gövdeyi okur; istemci aws-chunked akış imzası kullanmışsa parçaları birleştirir.
+func readS3Payload(r *http.Request) ([]byte, error) {
+	if !strings.HasPrefix(r.Header.Get("X-Amz-Content-Sha256"), "STREAMING-") {
+		return io.ReadAll(r.Body)
+	}
+	var payload bytes.Buffer
+	reader := bufio.NewReader(r.Body)
+	for {
+		header, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		sizeHex, _, _ := strings.Cut(strings.TrimSpace(header), ";")
+		size, err := strconv.ParseInt(sizeHex, 16, 64)
+		if err != nil {
+			return nil, err
+		}
+		if size == 0 {
+			return payload.Bytes(), nil
+		}
+		if _, err := io.CopyN(&payload, reader, size); err != nil {
+			return nil, err
+		}
+		if _, err := reader.Discard(2); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func writeS3Error(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	io.WriteString(w, "<Error><Code>"+code+"</Code><Message>"+code+"</Message></Error>")
+}
+
+func newTestS3Storage(t *testing.T) (*S3Storage, *httptest.Server) {
+	t.Helper()
+	fake := &fakeS3{bucket: "uploads", objects: map[string]fakeS3Object{}}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	endpoint, _ := url.Parse(server.URL)
+	s, err := NewS3Storage(S3Config{
+		Endpoint:     endpoint.Host,
+		AccessKey:    "test-access",
+		SecretKey:    "test-secret",
+		Bucket:       "uploads",
+		Region:       "us-east-1",
+		PathStyle:    true,
+		CreateBucket: true,
+	})
+	if err != nil {
+		t.Fatalf("S3 deposu oluşturulamadı: %v", err)
+	}
+	return s, server
+}
+
+func TestS3StoragePutOpenSignedURLDelete(t *testing.T) {
+	s, server := newTestS3Storage(t)
+	const key = "avatars/12/ab34_sm.png"
+	content := []byte("png-verisi")
+
+	if err := s.Put(key, bytes.NewReader(content), int64(len(content)), "image/png"); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	object, err := s.Open(key)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	body, err := io.ReadAll(object.Body)
+	object.Body.Close()
+	if err != nil {
+		t.Fatalf("Open gövdesi okunamadı: %v", err)
+	}
+	if !bytes.Equal(body, content) || object.ContentType != "image/png" || object.Size != int64(len(content)) {
+		t.Errorf("Open: gövde %q, tip %q, boyut %d", body, object.ContentType, object.Size)
+	}
+
+	signed, err := s.SignedURL(key, time.Minute)
+	if err != nil {
+		t.Fatalf("SignedURL: %v", err)
+	}
+	parsed, err := url.Parse(signed)
+	if err != nil {
+		t.Fatalf("imzalı adres çözümlenemedi: %v", err)
+	}
+	if !strings.HasPrefix(signed, server.URL+"/uploads/"+key) || parsed.Query().Get("X-Amz-Signature") == "" || parsed.Query().Get("X-Amz-Expires") != "60" {
+		t.Errorf("beklenmeyen imzalı adres: %s", signed)
+	}
+
+	if err := s.Delete(key); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := s.Open(key); err != ErrNotFound {
+		t.Errorf("silinen dosya için ErrNotFound bekleniyordu, alınan: %v", err)
+	}
+}
+
+func TestS3StorageRejectsInvalidKey(t *testing.T) {
+	s, _ := newTestS3Storage(t)
+	if err := s.Put("../disari.txt", strings.NewReader("x"), 1, ""); err != ErrInvalidKey {
+		t.Errorf("Put: ErrInvalidKey bekleniyordu, alınan: %v", err)
+	}
+	if _, err := s.SignedURL("/mutlak", time.Minute); err != ErrInvalidKey {
+		t.Errorf("SignedURL: ErrInvalidKey bekleniyordu, alınan: %v", err)
+	}
+}
+
+func TestLocalStoragePutOpenDelete(t *testing.T) {
+	s := NewLocalStorage(t.TempDir(), "/files/signed", []byte("secret"))
+	const key = "avatars/3/resim.png"
+
+	if err := s.Put(key, strings.NewReader("içerik"), -1, "image/png"); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	data, err := Get(s, key, 1024)
+	if err != nil || string(data) != "içerik" {
+		t.Fatalf("Get: veri %q, hata %v", data, err)
+	}
+	if _, err := Get(s, key, 2); err != ErrTooLarge {
+		t.Errorf("Get: ErrTooLarge bekleniyordu, alınan: %v", err)
+	}
+
+	if err := s.Delete(key, "avatars/3/olmayan.png"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := s.Open(key); err != ErrNotFound {
+		t.Errorf("silinen dosya için ErrNotFound bekleniyordu, alınan: %v", err)
+	}
+}
+
+func TestLocalStorageVerifySignedURL(t *testing.T) {
+	s := NewLocalStorage(t.TempDir(), "/files/signed/", []byte("secret"))
+	const key = "avatars/3/resim dosyası.png"
+
+	signed, err := s.SignedURL(key, time.Minute)
+	if err != nil {
+		t.Fatalf("SignedURL: %v", err)
+	}
+	parsed, err := url.Parse(signed)
+	if err != nil {
+		t.Fatalf("imzalı adres çözümlenemedi: %v", err)
+	}
+	signedKey := strings.TrimPrefix(parsed.Path, "/files/signed/")
+	expires, _ := strconv.ParseInt(parsed.Query().Get("expires"), 10, 64)
+	signature := parsed.Query().Get("signature")
+
+	if signedKey != key {
+		t.Fatalf("adresteki anahtar %q, beklenen %q", signedKey, key)
+	}
+	if !s.VerifySignedURL(signedKey, expires, signature) {
+		t.Fatal("geçerli imzalı adres reddedildi")
+	}
+
+	tampered := []byte(signature)
+	tampered[0] ^= 1
+	tests := []struct {
+		name      string
+		key       string
+		expires   int64
+		signature string
+	}{
+		{"değiştirilmiş imza", key, expires, string(tampered)},
+		{"başka anahtar", "avatars/4/resim.png", expires, signature},
+		{"uzatılmış süre", key, expires + 3600, signature},
+		{"geçersiz anahtar", "../" + key, expires, signature},
+	}
+	for _, tt := range tests {
+		if s.VerifySignedURL(tt.key, tt.expires, tt.signature) {
+			t.Errorf("%s kabul edildi", tt.name)
+		}
+	}
+
+	other := NewLocalStorage(t.TempDir(), "/files/signed", []byte("other-secret"))
+	if other.VerifySignedURL(key, expires, signature) {
+		t.Error("başka anahtarla imzalanmış adres kabul edildi")
+	}
+}
+
+func TestLocalStorageVerifySignedURLExpired(t *testing.T) {
+	s := NewLocalStorage(t.TempDir(), "/files/signed", []byte("secret"))
+	signed, err := s.SignedURL("avatars/3/resim.png", -time.Second)
+	if err != nil {
+		t.Fatalf("SignedURL: %v", err)
+	}
+	parsed, _ := url.Parse(signed)
+	expires, _ := strconv.ParseInt(parsed.Query().Get("expires"), 10, 64)
+	if s.VerifySignedURL("avatars/3/resim.png", expires, parsed.Query().Get("signature")) {
+		t.Error("süresi dolmuş imzalı adres kabul edildi")
+	}
+}
@@ -3,7 +3,9 @@ package utils
 const (
 	DefaultOrderBy = "desc"
 	DefaultSortBy  = "id"
-	DefaultPage    = 1
-	DefaultPerPage = 20
-	MaxPerPage     = 100
+	// SortByRelevance, arama yapılırken sonuçları arama terimine benzerliklerine göre sıralar.
+	SortByRelevance = "relevance"
+	DefaultPage     = 1
+	DefaultPerPage  = 20
+	MaxPerPage      = 100
 )
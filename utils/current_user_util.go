@@ -0,0 +1,35 @@
+package utils
+
+import (
+	"zatrano/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// CurrentUserLocalsKey, AuthMiddleware'in yüklediği kullanıcının c.Locals içindeki anahtarıdır.
+// Locals görünümlere aktarıldığından şablonlarda .CurrentUser olarak da erişilebilir.
+const CurrentUserLocalsKey = "CurrentUser"
+
+// SetCurrentUser, isteğin kimliği doğrulanmış kullanıcısını istek bağlamına yazar.
+func SetCurrentUser(c *fiber.Ctx, user *models.User) {
+	c.Locals(CurrentUserLocalsKey, user)
+}
+
+// CurrentUser, AuthMiddleware tarafından bu istek için yüklenen kullanıcıyı döner.
+// Kimlik doğrulaması yapılmamış isteklerde nil, false döner.
+func CurrentUser(c *fiber.Ctx) (*models.User, bool) {
+	user, ok := c.Locals(CurrentUserLocalsKey).(*models.User)
+	if !ok || user == nil {
+		return nil, false
+	}
+	return user, true
+}
+
+// CurrentUserID, isteğin kimliği doğrulanmış kullanıcısının ID'sini döner.
+func CurrentUserID(c *fiber.Ctx) (uint, bool) {
+	user, ok := CurrentUser(c)
+	if !ok {
+		return 0, false
+	}
+	return user.ID, true
+}
@@ -0,0 +1,58 @@
+package utils
+
+import (
+	"encoding/base64"
+	"encoding/json"
+)
+
+const (
+	PagingOffset = "offset"
+	PagingCursor = "cursor"
+)
+
+// Cursor, keyset sayfalamada bir sayfanın sınırındaki kaydı tanımlar. Value, sıralama sütununun o kayıttaki
+// değeridir ve ID ile birlikte eşit değerli satırlar arasında kesin bir sıra sağlar.
+type Cursor struct {
+	SortBy   string `json:"s"`
+	OrderBy  string `json:"o"`
+	Value    string `json:"v"`
+	ID       uint   `json:"id"`
+	Backward bool   `json:"b,omitempty"`
+}
+
+// Encode, imleci URL'de taşınabilecek opak bir metne çevirir.
+func (c Cursor) Encode() string {
+	raw, err := json.Marshal(c)
+	if err != nil {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// DecodeCursor, Encode ile üretilmiş metni çözer. Bozuk imleçlerde false döner.
+func DecodeCursor(token string) (Cursor, bool) {
+	var cursor Cursor
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return cursor, false
+	}
+	if err := json.Unmarshal(raw, &cursor); err != nil || cursor.ID == 0 {
+		return cursor, false
+	}
+	return cursor, true
+}
+
+// CursorMeta, keyset sayfalamanın sayfa bilgileridir. TotalEstimated true ise TotalItems, Postgres
+// istatistiklerinden alınmış yaklaşık bir değerdir.
+type CursorMeta struct {
+	PerPage        int    `json:"per_page"`
+	TotalItems     int64  `json:"total_items"`
+	TotalEstimated bool   `json:"total_estimated"`
+	NextCursor     string `json:"next_cursor,omitempty"`
+	PrevCursor     string `json:"prev_cursor,omitempty"`
+}
+
+type CursorPaginatedResult struct {
+	Data interface{} `json:"data"`
+	Meta CursorMeta  `json:"meta"`
+}
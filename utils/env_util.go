@@ -26,6 +26,19 @@ func GetEnvAsInt(key string, defaultValue int) int {
 	return valueInt
 }
 
+func GetEnvAsBool(key string, defaultValue bool) bool {
+	value, err := strconv.ParseBool(os.Getenv(key))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
 func IsProduction() bool {
 	return os.Getenv("APP_ENV") == "production"
 }
+
+// IsDevelopment, APP_ENV boşsa da true döner; logger da bu durumda geliştirme ayarlarını kullanır.
+func IsDevelopment() bool {
+	return GetEnvWithDefault("APP_ENV", "development") == "development"
+}
@@ -1,6 +1,11 @@
 package utils
 
-import "math"
+import (
+	"math"
+	"net/url"
+	"strconv"
+	"time"
+)
 
 type ListParams struct {
 	Name string `query:"name"`
@@ -10,6 +15,87 @@ type ListParams struct {
 
 	Page    int `query:"page"`
 	PerPage int `query:"perPage"`
+
+	// Paging, "offset" veya "cursor" olabilir; boşsa ekranın varsayılan modu kullanılır.
+	Paging string `query:"paging"`
+	// Cursor, keyset sayfalamada gidilecek sayfanın imlecidir; Page yerine kullanılır.
+	Cursor string `query:"cursor"`
+}
+
+// SecurityEventFilter, güvenlik olayı listesinde ListParams'a ek olarak kullanılan filtrelerdir.
+type SecurityEventFilter struct {
+	UserID  uint   `query:"userId"`
+	Type    string `query:"type"`
+	Outcome string `query:"outcome"`
+	IP      string `query:"ip"`
+}
+
+// UserFilter, kullanıcı listesinde ListParams'a ek olarak kullanılan filtrelerdir.
+type UserFilter struct {
+	TeamID  uint   `query:"teamId"`
+	Account string `query:"account"`
+	// Status, "active" veya "inactive" olabilir; boş bırakılırsa tüm durumlar listelenir.
+	Status string `query:"status"`
+	Type   string `query:"type"`
+	// CreatedFrom ve CreatedTo, oluşturulma tarihi aralığıdır (YYYY-MM-DD, iki uç da dahil).
+	CreatedFrom string `query:"createdFrom"`
+	CreatedTo   string `query:"createdTo"`
+	// ScopeTeamID, yalnızca kendi takımını yönetebilen kullanıcılar için sunucu tarafında ayarlanır; sorgudan okunmaz.
+	ScopeTeamID *uint `query:"-"`
+	// OnlyDeleted, listeyi yalnızca silinmiş (çöp kutusundaki) kullanıcılarla sınırlar; sunucu tarafında ayarlanır.
+	OnlyDeleted bool `query:"-"`
+}
+
+const (
+	UserStatusActive   = "active"
+	UserStatusInactive = "inactive"
+	filterDateLayout   = "2006-01-02"
+)
+
+// CreatedRange, tarih aralığını [from, to) olarak döner; to, bitiş gününün ertesi gününün başlangıcıdır.
+// Boş veya hatalı biçimli uçlar nil döner ve filtrelemede yok sayılır.
+func (f UserFilter) CreatedRange() (from, to *time.Time) {
+	if t, err := time.ParseInLocation(filterDateLayout, f.CreatedFrom, time.Local); err == nil {
+		from = &t
+	}
+	if t, err := time.ParseInLocation(filterDateLayout, f.CreatedTo, time.Local); err == nil {
+		next := t.AddDate(0, 0, 1)
+		to = &next
+	}
+	return from, to
+}
+
+// QueryString, sayfalama ve sıralama bağlantılarına eklenecek "&teamId=..&status=.." biçimindeki filtre
+// parçasını döner. Ad filtresi ListParams ile ayrıca taşındığı için dahil edilmez.
+func (f UserFilter) QueryString() string {
+	values := url.Values{}
+	if f.TeamID > 0 {
+		values.Set("teamId", strconv.FormatUint(uint64(f.TeamID), 10))
+	}
+	if f.Account != "" {
+		values.Set("account", f.Account)
+	}
+	if f.Status != "" {
+		values.Set("status", f.Status)
+	}
+	if f.Type != "" {
+		values.Set("type", f.Type)
+	}
+	if f.CreatedFrom != "" {
+		values.Set("createdFrom", f.CreatedFrom)
+	}
+	if f.CreatedTo != "" {
+		values.Set("createdTo", f.CreatedTo)
+	}
+	if len(values) == 0 {
+		return ""
+	}
+	return "&" + values.Encode()
+}
+
+// IsActive, listede ad dışında en az bir filtre uygulanıp uygulanmadığını belirtir.
+func (f UserFilter) IsActive() bool {
+	return f.QueryString() != ""
 }
 
 type PaginationMeta struct {
@@ -0,0 +1,33 @@
+package utils
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const RememberMeCookieName = "remember_me"
+
+func SetRememberMeCookie(c *fiber.Ctx, value string, expiresAt time.Time) {
+	c.Cookie(&fiber.Cookie{
+		Name:     RememberMeCookieName,
+		Value:    value,
+		Path:     "/",
+		Expires:  expiresAt,
+		HTTPOnly: true,
+		Secure:   IsProduction(),
+		SameSite: fiber.CookieSameSiteLaxMode,
+	})
+}
+
+func ClearRememberMeCookie(c *fiber.Ctx) {
+	c.Cookie(&fiber.Cookie{
+		Name:     RememberMeCookieName,
+		Value:    "",
+		Path:     "/",
+		Expires:  time.Unix(0, 0),
+		HTTPOnly: true,
+		Secure:   IsProduction(),
+		SameSite: fiber.CookieSameSiteLaxMode,
+	})
+}
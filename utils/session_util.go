@@ -1,6 +1,8 @@
 package utils
 
 import (
+	"time"
+
 	"zatrano/models"
 
 	"github.com/gofiber/fiber/v2"
@@ -44,3 +46,43 @@ func GetUserStatusFromSession(sess *session.Session) (bool, error) {
 	return userStatus, nil
 
 }
+
+// IsSessionStale, oturum kullanıcının şifresi, tipi veya durumu değişmeden önce açıldıysa true döner.
+func IsSessionStale(sess *session.Session, user *models.User) bool {
+	version, _ := sess.Get("session_version").(int)
+	return version != user.SessionVersion
+}
+
+// RefreshSessionUser, oturumdaki kullanıcı bilgilerini veritabanındaki güncel değerlerle eşitler.
+func RefreshSessionUser(sess *session.Session, user *models.User) error {
+	name, _ := sess.Get("user_name").(string)
+	userType, _ := sess.Get("user_type").(string)
+	status, _ := sess.Get("user_status").(bool)
+	changeRequired, _ := sess.Get("password_change_required").(bool)
+	required := user.PasswordChangeRequired()
+	if name == user.Name && userType == string(user.Type) && status == user.Status && changeRequired == required {
+		return nil
+	}
+
+	sess.Set("user_name", user.Name)
+	sess.Set("user_type", string(user.Type))
+	sess.Set("user_status", user.Status)
+	sess.Set("password_change_required", required)
+	return sess.Save()
+}
+
+// EstablishUserSession, oturum sabitlemeye karşı oturum kimliğini yeniler ve kimliği doğrulanmış kullanıcının
+// bilgilerini oturuma yazar. Kaydetmek çağırana aittir.
+func EstablishUserSession(sess *session.Session, user *models.User) error {
+	if err := sess.Regenerate(); err != nil {
+		return err
+	}
+	sess.Set("user_id", user.ID)
+	sess.Set("user_type", string(user.Type))
+	sess.Set("user_status", user.Status)
+	sess.Set("user_name", user.Name)
+	sess.Set("auth_at", time.Now().Unix())
+	sess.Set("session_version", user.SessionVersion)
+	sess.Set("password_change_required", user.PasswordChangeRequired())
+	return nil
+}
@@ -71,6 +71,13 @@ func TemplateHelpers() template.FuncMap {
 			}
 			return t.Format("02.01.2006 15:04")
 		},
+
+		"FormatDateTimePtr": func(t *time.Time) string {
+			if t == nil || t.IsZero() {
+				return ""
+			}
+			return t.Format("02.01.2006 15:04")
+		},
 	}
 	return fm
 }
@@ -0,0 +1,101 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// rfc6238Secret, RFC 6238 Ek B'deki SHA1 test anahtarının ("12345678901234567890") base32 karşılığıdır.
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+// RFC 6238 Ek B'deki 8 haneli SHA1 kodlarının son 6 hanesi.
+var rfc6238Vectors = []struct {
+	unix int64
+	code string
+}{
+	{59, "287082"},
+	{1111111109, "081804"},
+	{1111111111, "050471"},
+	{1234567890, "005924"},
+	{2000000000, "279037"},
+	{20000000000, "353130"},
+}
+
+func TestTOTPCodeAtRFC6238Vectors(t *testing.T) {
+	for _, v := range rfc6238Vectors {
+		code, err := TOTPCodeAt(rfc6238Secret, TOTPStep(time.Unix(v.unix, 0)))
+		if err != nil {
+			t.Fatalf("T=%d: beklenmeyen hata: %v", v.unix, err)
+		}
+		if code != v.code {
+			t.Errorf("T=%d: kod %s, beklenen %s", v.unix, code, v.code)
+		}
+	}
+}
+
+func TestTOTPCodeAtAcceptsLowercaseSecret(t *testing.T) {
+	code, err := TOTPCodeAt(" gezdgnbvgy3tqojqgezdgnbvgy3tqojq ", TOTPStep(time.Unix(59, 0)))
+	if err != nil || code != "287082" {
+		t.Fatalf("kod %q, hata %v", code, err)
+	}
+}
+
+func TestTOTPCodeAtRejectsInvalidSecret(t *testing.T) {
+	if _, err := TOTPCodeAt("not base32!", 1); err == nil {
+		t.Fatal("geçersiz anahtar için hata bekleniyordu")
+	}
+}
+
+func TestValidateTOTPCode(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	step := TOTPStep(now)
+	codeAt := func(s int64) string {
+		code, err := TOTPCodeAt(rfc6238Secret, s)
+		if err != nil {
+			t.Fatalf("adım %d: %v", s, err)
+		}
+		return code
+	}
+
+	tests := []struct {
+		name     string
+		code     string
+		wantStep int64
+		wantOK   bool
+	}{
+		{"güncel adım", codeAt(step), step, true},
+		{"bir önceki adım", codeAt(step - 1), step - 1, true},
+		{"bir sonraki adım", codeAt(step + 1), step + 1, true},
+		{"iki adım önce", codeAt(step - 2), 0, false},
+		{"iki adım sonra", codeAt(step + 2), 0, false},
+		{"boşluklu kod", "050 471", step, true},
+		{"kısa kod", "05047", 0, false},
+		{"uzun kod", "0504710", 0, false},
+		{"boş kod", "", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotStep, ok := ValidateTOTPCode(rfc6238Secret, tt.code, now)
+			if ok != tt.wantOK || gotStep != tt.wantStep {
+				t.Errorf("ValidateTOTPCode(%q) = (%d, %v), beklenen (%d, %v)", tt.code, gotStep, ok, tt.wantStep, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestValidateTOTPCodeRejectsInvalidSecret(t *testing.T) {
+	if _, ok := ValidateTOTPCode("not base32!", "123456", time.Now()); ok {
+		t.Fatal("geçersiz anahtarla kod kabul edildi")
+	}
+}
+
+func TestTOTPQRCodeDataURI(t *testing.T) {
+	uri, err := TOTPQRCodeDataURI(TOTPProvisioningURI("Zatrano", "ali", rfc6238Secret), 180)
+	if err != nil {
+		t.Fatalf("beklenmeyen hata: %v", err)
+	}
+	if !strings.HasPrefix(uri, "data:image/png;base64,") {
+		t.Errorf("beklenmeyen data URI: %.40s", uri)
+	}
+}